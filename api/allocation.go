@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// IssueShare is one issue's part of an Allocation: either a percentage of what is left after
+// fixed shares, or a fixed number of seconds.
+type IssueShare struct {
+	IssueID string
+	Percent float64
+	Seconds int
+}
+
+// Allocation spreads one category's time across several issues.
+type Allocation []IssueShare
+
+// Split divides totalSeconds between the allocation's issues, returning the seconds for each share in order.
+// Fixed shares are taken first and percentages split the rest in whole increments using the largest
+// remainder method, so the result always adds up to exactly totalSeconds.
+func (a Allocation) Split(totalSeconds int, increment time.Duration) ([]int, error) {
+	if len(a) == 0 {
+		return nil, fmt.Errorf("allocation has no issues")
+	}
+
+	split := make([]int, len(a))
+	fixed := 0
+	percent := 0.0
+	for i, share := range a {
+		split[i] = share.Seconds
+		fixed += share.Seconds
+		percent += share.Percent
+	}
+	remaining := totalSeconds - fixed
+	if remaining < 0 {
+		return nil, fmt.Errorf("allocation assigns %s but only %s is being logged", FormatHours(fixed), FormatHours(totalSeconds))
+	}
+	if percent == 0 {
+		if remaining != 0 {
+			return nil, fmt.Errorf("allocation assigns %s but %s is being logged; use percentages to share the rest", FormatHours(fixed), FormatHours(totalSeconds))
+		}
+		return split, nil
+	}
+	if math.Abs(percent-100) > 0.001 {
+		return nil, fmt.Errorf("allocation percentages add up to %g%%, not 100%%", percent)
+	}
+
+	step := int(increment / time.Second)
+	if step <= 0 {
+		step = int(DefaultIncrement / time.Second)
+	}
+	units := remaining / step
+	leftover := remaining % step
+
+	type fraction struct {
+		share     int
+		remainder float64
+	}
+	var fractions []fraction
+	placed := 0
+	for i, share := range a {
+		if share.Percent == 0 {
+			continue
+		}
+		exact := float64(units) * share.Percent / 100
+		whole := int(math.Floor(exact))
+		split[i] += whole * step
+		placed += whole
+		fractions = append(fractions, fraction{share: i, remainder: exact - float64(whole)})
+	}
+
+	// Hand the units lost to rounding down to the shares that lost the most, earlier shares first on ties
+	sort.SliceStable(fractions, func(i, j int) bool { return fractions[i].remainder > fractions[j].remainder })
+	for i := 0; i < units-placed; i++ {
+		split[fractions[i%len(fractions)].share] += step
+	}
+	split[fractions[0].share] += leftover
+	return split, nil
+}
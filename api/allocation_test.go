@@ -0,0 +1,103 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAllocationSplit(t *testing.T) {
+	hour := secondsPerHour
+
+	tests := []struct {
+		name       string
+		allocation Allocation
+		total      int
+		increment  time.Duration
+		expected   []int
+		wantErr    bool
+	}{
+		{
+			name:       "even percentages",
+			allocation: Allocation{{IssueID: "1", Percent: 60}, {IssueID: "2", Percent: 40}},
+			total:      30 * hour,
+			increment:  15 * time.Minute,
+			expected:   []int{18 * hour, 12 * hour},
+		},
+		{
+			name:       "thirds keep the total exact",
+			allocation: Allocation{{IssueID: "1", Percent: 33.33}, {IssueID: "2", Percent: 33.33}, {IssueID: "3", Percent: 33.34}},
+			total:      10 * hour,
+			increment:  15 * time.Minute,
+			expected:   []int{11700, 11700, 12600},
+		},
+		{
+			name:       "sub increment leftover",
+			allocation: Allocation{{IssueID: "1", Percent: 50}, {IssueID: "2", Percent: 50}},
+			total:      hour + 600,
+			increment:  15 * time.Minute,
+			expected:   []int{1800 + 600, 1800},
+		},
+		{
+			name:       "fixed hours and a percentage of the rest",
+			allocation: Allocation{{IssueID: "1", Seconds: 10 * hour}, {IssueID: "2", Percent: 50}, {IssueID: "3", Percent: 50}},
+			total:      30 * hour,
+			increment:  time.Hour,
+			expected:   []int{10 * hour, 10 * hour, 10 * hour},
+		},
+		{
+			name:       "fixed hours only",
+			allocation: Allocation{{IssueID: "1", Seconds: 20 * hour}, {IssueID: "2", Seconds: 10 * hour}},
+			total:      30 * hour,
+			expected:   []int{20 * hour, 10 * hour},
+		},
+		{
+			name:       "fixed hours short of the total",
+			allocation: Allocation{{IssueID: "1", Seconds: 20 * hour}},
+			total:      30 * hour,
+			wantErr:    true,
+		},
+		{
+			name:       "fixed hours over the total",
+			allocation: Allocation{{IssueID: "1", Seconds: 20 * hour}, {IssueID: "2", Percent: 100}},
+			total:      10 * hour,
+			wantErr:    true,
+		},
+		{
+			name:       "percentages not 100",
+			allocation: Allocation{{IssueID: "1", Percent: 60}, {IssueID: "2", Percent: 30}},
+			total:      10 * hour,
+			wantErr:    true,
+		},
+		{
+			name:    "empty",
+			total:   10 * hour,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.allocation.Split(tt.total, tt.increment)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Split() = %v, want %v", got, tt.expected)
+			}
+			sum := 0
+			for _, seconds := range got {
+				sum += seconds
+			}
+			if sum != tt.total {
+				t.Errorf("Split() adds up to %d, want %d", sum, tt.total)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Tempo v4 REST API root used when no base URL is configured.
+	DefaultBaseURL   = "https://api.tempo.io/4"
+	defaultUserAgent = "devctl-timecard"
+)
+
+// Client talks to the Tempo REST API. Construct one with NewClient.
+type Client struct {
+	baseURL     string
+	bearerToken string
+	// username and password replace the bearer token with basic auth when set
+	username  string
+	password  string
+	userAgent string
+	// service names the API in retry messages
+	service    string
+	timeout    time.Duration
+	httpClient *http.Client
+	retry      RetryPolicy
+	sleep      func(time.Duration)
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at a different Tempo API root, e.g. a regional
+// endpoint or a local stand-in used in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithHTTPClient sets the underlying HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithTimeout sets the overall timeout for a single HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// withService names the API in retry messages.
+func withService(service string) Option {
+	return func(c *Client) {
+		c.service = service
+	}
+}
+
+// withBasicAuth authenticates with a username and password instead of a bearer token.
+func withBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.bearerToken = ""
+		c.username = username
+		c.password = strings.TrimSpace(password)
+	}
+}
+
+// NewClient creates a Tempo API client authenticated with the given bearer token.
+func NewClient(bearerToken string, opts ...Option) *Client {
+	c := newClient(opts, withService("Tempo"))
+	c.bearerToken = cleanBearerToken(bearerToken)
+	return c
+}
+
+// newClient creates a client with the default settings changed by opts, then by fixed, which the caller's
+// options cannot override. It is shared by the Tempo and Jira clients.
+func newClient(opts []Option, fixed ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		userAgent:  defaultUserAgent,
+		httpClient: &http.Client{},
+		retry:      DefaultRetryPolicy(),
+		sleep:      time.Sleep,
+	}
+	for _, opt := range append(opts, fixed...) {
+		opt(c)
+	}
+	if c.timeout > 0 {
+		// Copy so a caller-supplied http.Client is never mutated
+		httpClient := *c.httpClient
+		httpClient.Timeout = c.timeout
+		c.httpClient = &httpClient
+	}
+	return c
+}
+
+// BaseURL returns the API root the client sends requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// newRequest builds an authenticated request for a path relative to the base URL.
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	return c.newRequestURL(method, c.baseURL+path, body)
+}
+
+// newRequestURL builds an authenticated request for a full URL. Callers make sure it is on the base URL's host.
+func (c *Client) newRequestURL(method, requestURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	} else {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.bearerToken))
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	return req, nil
+}
+
+// do sends a request using the configured HTTP client, retrying according to the retry policy.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.MaxAttempts || !shouldRetry(req.Method, resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+			}
+			return resp, nil
+		}
+
+		delay := c.retry.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("⏳ Request to %s failed (%v), retrying in %s (attempt %d/%d)\n", c.service, err, delay.Round(time.Millisecond), attempt+1, c.retry.MaxAttempts)
+		} else {
+			log.Printf("⏳ %s returned HTTP %d, retrying in %s (attempt %d/%d)\n", c.service, resp.StatusCode, delay.Round(time.Millisecond), attempt+1, c.retry.MaxAttempts)
+			drainAndClose(resp)
+		}
+		c.sleep(delay)
+	}
+}
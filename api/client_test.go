@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Defaults(t *testing.T) {
+	client := NewClient("  token\n")
+
+	if client.BaseURL() != DefaultBaseURL {
+		t.Errorf("BaseURL() = %q, want %q", client.BaseURL(), DefaultBaseURL)
+	}
+	if client.bearerToken != "token" {
+		t.Errorf("bearerToken = %q, want %q", client.bearerToken, "token")
+	}
+	if client.userAgent != defaultUserAgent {
+		t.Errorf("userAgent = %q, want %q", client.userAgent, defaultUserAgent)
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	httpClient := &http.Client{}
+	client := NewClient("token",
+		WithBaseURL("https://api.eu.tempo.io/4/"),
+		WithHTTPClient(httpClient),
+		WithUserAgent("custom-agent"),
+		WithTimeout(5*time.Second),
+	)
+
+	if client.BaseURL() != "https://api.eu.tempo.io/4" {
+		t.Errorf("BaseURL() = %q, want trailing slash trimmed", client.BaseURL())
+	}
+	if client.userAgent != "custom-agent" {
+		t.Errorf("userAgent = %q, want %q", client.userAgent, "custom-agent")
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want %v", client.httpClient.Timeout, 5*time.Second)
+	}
+	if httpClient.Timeout != 0 {
+		t.Error("WithTimeout should not mutate the caller's http.Client")
+	}
+}
+
+func TestNewClient_EmptyOptionsKeepDefaults(t *testing.T) {
+	client := NewClient("token", WithBaseURL(""), WithHTTPClient(nil), WithUserAgent(""))
+
+	if client.BaseURL() != DefaultBaseURL {
+		t.Errorf("BaseURL() = %q, want %q", client.BaseURL(), DefaultBaseURL)
+	}
+	if client.httpClient == nil {
+		t.Error("httpClient should not be nil")
+	}
+	if client.userAgent != defaultUserAgent {
+		t.Errorf("userAgent = %q, want %q", client.userAgent, defaultUserAgent)
+	}
+}
+
+func TestClientNewRequest_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "custom-agent" {
+			t.Errorf("User-Agent = %q, want %q", got, "custom-agent")
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithBaseURL(server.URL), WithUserAgent("custom-agent"))
+	req, err := client.newRequest("GET", "/worklogs", nil)
+	if err != nil {
+		t.Fatalf("newRequest() error = %v", err)
+	}
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	resp.Body.Close()
+}
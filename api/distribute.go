@@ -0,0 +1,177 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Distributor decides how a category's total time is spread across the working days of a week.
+type Distributor interface {
+	// Distribute returns the seconds to log on each of the given number of days, in order.
+	// The result may be shorter than days, and may contain zeros for days that get nothing.
+	Distribute(totalSeconds, days int) ([]int, error)
+}
+
+// Names of the built-in distribution strategies, as accepted by ParseDistributor.
+const (
+	FrontLoadedStrategy = "front-loaded"
+	EvenStrategy        = "even"
+	BackLoadedStrategy  = "back-loaded"
+	FillDaysStrategy    = "fill-days"
+	CapStrategyPrefix   = "cap:"
+)
+
+// FrontLoaded logs small totals an hour a day and splits larger ones evenly, giving earlier
+// days any remainder. This is the historical behaviour and the default.
+type FrontLoaded struct {
+	Increment time.Duration
+}
+
+func (d FrontLoaded) Distribute(totalSeconds, days int) ([]int, error) {
+	return distributeSeconds(totalSeconds, days, d.Increment), nil
+}
+
+// BackLoaded mirrors FrontLoaded so that later days get the remainder and small totals land at the end of the week.
+type BackLoaded struct {
+	Increment time.Duration
+}
+
+func (d BackLoaded) Distribute(totalSeconds, days int) ([]int, error) {
+	front := distributeSeconds(totalSeconds, days, d.Increment)
+	if front == nil {
+		return nil, nil
+	}
+	perDay := make([]int, days)
+	for i, seconds := range front {
+		perDay[days-1-i] = seconds
+	}
+	return perDay, nil
+}
+
+// Even splits every total across all days in whole increments, however small it is.
+type Even struct {
+	Increment time.Duration
+}
+
+func (d Even) Distribute(totalSeconds, days int) ([]int, error) {
+	if totalSeconds <= 0 || days <= 0 {
+		return nil, nil
+	}
+	return splitIncrements(totalSeconds, days, d.Increment), nil
+}
+
+// FillDays fills each day up to DayLength before moving on to the next, e.g. 8 hours Monday, then Tuesday.
+type FillDays struct {
+	DayLength time.Duration
+}
+
+func (d FillDays) Distribute(totalSeconds, days int) ([]int, error) {
+	if totalSeconds <= 0 || days <= 0 {
+		return nil, nil
+	}
+	dayLength := int(d.DayLength / time.Second)
+	if dayLength <= 0 {
+		return nil, fmt.Errorf("fill-days needs a positive day length")
+	}
+	if totalSeconds > dayLength*days {
+		return nil, fmt.Errorf("%s does not fit in %d days of %s", FormatHours(totalSeconds), days, FormatHours(dayLength))
+	}
+
+	var perDay []int
+	for remaining := totalSeconds; remaining > 0; remaining -= dayLength {
+		perDay = append(perDay, min(remaining, dayLength))
+	}
+	return perDay, nil
+}
+
+// Capped splits time evenly but never logs more than Cap on a single day.
+type Capped struct {
+	Cap       time.Duration
+	Increment time.Duration
+}
+
+func (d Capped) Distribute(totalSeconds, days int) ([]int, error) {
+	if totalSeconds <= 0 || days <= 0 {
+		return nil, nil
+	}
+	limit := int(d.Cap / time.Second)
+	if limit <= 0 {
+		return nil, fmt.Errorf("a per-day cap must be positive")
+	}
+	if totalSeconds > limit*days {
+		return nil, fmt.Errorf("%s does not fit in %d days capped at %s", FormatHours(totalSeconds), days, FormatHours(limit))
+	}
+
+	perDay := splitIncrements(totalSeconds, days, d.Increment)
+	// Move anything over the cap onto days that still have room
+	overflow := 0
+	for i := range perDay {
+		if perDay[i] > limit {
+			overflow += perDay[i] - limit
+			perDay[i] = limit
+		}
+	}
+	for i := range perDay {
+		room := min(overflow, limit-perDay[i])
+		perDay[i] += room
+		overflow -= room
+	}
+	return perDay, nil
+}
+
+// Scheduled fills each day up to the seconds it still requires before moving on to the next, so when every
+// category of a week is placed against the same Required, each day adds up to its schedule and small totals
+// land on as few days as possible. Required is used up as time is placed. Time beyond what is required is
+// spread like FrontLoaded.
+type Scheduled struct {
+	// Required is the seconds each day still needs, in order; share it between categories of one week
+	Required  []int
+	Increment time.Duration
+}
+
+func (d Scheduled) Distribute(totalSeconds, days int) ([]int, error) {
+	if totalSeconds <= 0 || days <= 0 {
+		return nil, nil
+	}
+	if len(d.Required) != days {
+		return nil, fmt.Errorf("the schedule has %d day(s) but time is spread across %d", len(d.Required), days)
+	}
+
+	perDay := make([]int, days)
+	remaining := totalSeconds
+	for day := range d.Required {
+		placed := min(remaining, max(d.Required[day], 0))
+		perDay[day] = placed
+		d.Required[day] -= placed
+		remaining -= placed
+	}
+	for day, seconds := range distributeSeconds(remaining, days, d.Increment) {
+		perDay[day] += seconds
+	}
+	return perDay, nil
+}
+
+// ParseDistributor builds a distributor from its name: front-loaded (also the default for ""), even,
+// back-loaded, fill-days, or cap:<duration> such as cap:6h.
+func ParseDistributor(name string, increment, dayLength time.Duration) (Distributor, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case name == "" || name == FrontLoadedStrategy:
+		return FrontLoaded{Increment: increment}, nil
+	case name == EvenStrategy:
+		return Even{Increment: increment}, nil
+	case name == BackLoadedStrategy:
+		return BackLoaded{Increment: increment}, nil
+	case name == FillDaysStrategy:
+		return FillDays{DayLength: dayLength}, nil
+	case strings.HasPrefix(name, CapStrategyPrefix):
+		limit, err := time.ParseDuration(strings.TrimPrefix(name, CapStrategyPrefix))
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid per-day cap %q, expected something like %s6h", name, CapStrategyPrefix)
+		}
+		return Capped{Cap: limit, Increment: increment}, nil
+	}
+	return nil, fmt.Errorf("unknown distribution strategy %q, expected one of %s, %s, %s, %s or %s<duration>",
+		name, FrontLoadedStrategy, EvenStrategy, BackLoadedStrategy, FillDaysStrategy, CapStrategyPrefix)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func sumSeconds(perDay []int) int {
+	sum := 0
+	for _, seconds := range perDay {
+		sum += seconds
+	}
+	return sum
+}
+
+func equalSeconds(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScheduledCategoriesAddUpToEachDay(t *testing.T) {
+	const hour = secondsPerHour
+
+	tests := []struct {
+		name     string
+		required []int
+		totals   []int
+	}{
+		{name: "standard week", required: []int{8 * hour, 8 * hour, 8 * hour, 8 * hour, 8 * hour}, totals: []int{32 * hour, 8 * hour, 0}},
+		{name: "uneven categories", required: []int{8 * hour, 8 * hour, 8 * hour, 8 * hour, 8 * hour}, totals: []int{29*hour + 900, 8*hour + 2700, 2 * hour}},
+		{name: "four tens", required: []int{10 * hour, 10 * hour, 10 * hour, 10 * hour, 0}, totals: []int{1800, 37 * hour, 2*hour + 1800}},
+		{name: "part-time", required: []int{0, 4 * hour, 6*hour + 1800, 0, 4 * hour}, totals: []int{7 * hour, 7*hour + 1800}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining := append([]int(nil), tt.required...)
+			days := make([]int, len(tt.required))
+			for _, total := range tt.totals {
+				perDay, err := Scheduled{Required: remaining, Increment: DefaultIncrement}.Distribute(total, len(days))
+				if err != nil {
+					t.Fatalf("Distribute(%d) error = %v", total, err)
+				}
+				for day, seconds := range perDay {
+					days[day] += seconds
+				}
+			}
+			if !equalSeconds(days, tt.required) {
+				t.Errorf("days add up to %v, want the schedule %v", days, tt.required)
+			}
+		})
+	}
+}
+
+func TestDistributors(t *testing.T) {
+	const hour = secondsPerHour
+
+	tests := []struct {
+		name        string
+		distributor Distributor
+		total       int
+		days        int
+		expected    []int
+		wantErr     bool
+	}{
+		{name: "front-loaded small total", distributor: FrontLoaded{Increment: DefaultIncrement}, total: 3 * hour, days: 5, expected: []int{hour, hour, hour}},
+		{name: "front-loaded remainder", distributor: FrontLoaded{Increment: time.Hour}, total: 7 * hour, days: 5, expected: []int{2 * hour, 2 * hour, hour, hour, hour}},
+		{name: "back-loaded small total", distributor: BackLoaded{Increment: DefaultIncrement}, total: 3 * hour, days: 5, expected: []int{0, 0, hour, hour, hour}},
+		{name: "back-loaded remainder", distributor: BackLoaded{Increment: time.Hour}, total: 7 * hour, days: 5, expected: []int{hour, hour, hour, 2 * hour, 2 * hour}},
+		{name: "even small total", distributor: Even{Increment: DefaultIncrement}, total: 3 * hour, days: 5, expected: []int{2700, 2700, 1800, 1800, 1800}},
+		{name: "even full week", distributor: Even{Increment: DefaultIncrement}, total: 40 * hour, days: 5, expected: []int{8 * hour, 8 * hour, 8 * hour, 8 * hour, 8 * hour}},
+		{name: "fill-days", distributor: FillDays{DayLength: 8 * time.Hour}, total: 20 * hour, days: 5, expected: []int{8 * hour, 8 * hour, 4 * hour}},
+		{name: "fill-days overflow", distributor: FillDays{DayLength: 8 * time.Hour}, total: 41 * hour, days: 5, wantErr: true},
+		{name: "fill-days no day length", distributor: FillDays{}, total: hour, days: 5, wantErr: true},
+		{name: "capped evenly", distributor: Capped{Cap: 6 * time.Hour, Increment: DefaultIncrement}, total: 25 * hour, days: 5, expected: []int{5 * hour, 5 * hour, 5 * hour, 5 * hour, 5 * hour}},
+		{name: "capped at limit", distributor: Capped{Cap: 6 * time.Hour, Increment: 4 * time.Hour}, total: 28 * hour, days: 5, expected: []int{6 * hour, 6 * hour, 6 * hour, 6 * hour, 4 * hour}},
+		{name: "capped overflow", distributor: Capped{Cap: 6 * time.Hour, Increment: DefaultIncrement}, total: 31 * hour, days: 5, wantErr: true},
+		{name: "zero total", distributor: Even{Increment: DefaultIncrement}, total: 0, days: 5, expected: nil},
+		{name: "scheduled four tens", distributor: Scheduled{Required: []int{10 * hour, 10 * hour, 10 * hour, 10 * hour, 0}, Increment: DefaultIncrement}, total: 40 * hour, days: 5, expected: []int{10 * hour, 10 * hour, 10 * hour, 10 * hour, 0}},
+		{name: "scheduled part-time", distributor: Scheduled{Required: []int{0, 4 * hour, 8 * hour}, Increment: time.Hour}, total: 6 * hour, days: 3, expected: []int{0, 4 * hour, 2 * hour}},
+		{name: "scheduled small total on one day", distributor: Scheduled{Required: []int{0, 6 * hour, 6 * hour}, Increment: time.Hour}, total: 5*hour + 600, days: 3, expected: []int{0, 5*hour + 600, 0}},
+		{name: "scheduled beyond the schedule", distributor: Scheduled{Required: []int{8 * hour, 8 * hour}, Increment: time.Hour}, total: 18 * hour, days: 2, expected: []int{9 * hour, 9 * hour}},
+		{name: "scheduled with nothing required", distributor: Scheduled{Required: []int{0, 0}, Increment: time.Hour}, total: 3 * hour, days: 2, expected: []int{2 * hour, hour}},
+		{name: "scheduled day count mismatch", distributor: Scheduled{Required: []int{8 * hour}, Increment: time.Hour}, total: hour, days: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.distributor.Distribute(tt.total, tt.days)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Distribute() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Distribute() error = %v", err)
+			}
+			if !equalSeconds(got, tt.expected) {
+				t.Errorf("Distribute(%d, %d) = %v, want %v", tt.total, tt.days, got, tt.expected)
+			}
+			if sumSeconds(got) != tt.total {
+				t.Errorf("sum = %d, want %d", sumSeconds(got), tt.total)
+			}
+		})
+	}
+}
+
+func TestParseDistributor(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Distributor
+		wantErr  bool
+	}{
+		{name: "", expected: FrontLoaded{Increment: time.Hour}},
+		{name: "front-loaded", expected: FrontLoaded{Increment: time.Hour}},
+		{name: "Even", expected: Even{Increment: time.Hour}},
+		{name: "back-loaded", expected: BackLoaded{Increment: time.Hour}},
+		{name: "fill-days", expected: FillDays{DayLength: 8 * time.Hour}},
+		{name: "cap:6h", expected: Capped{Cap: 6 * time.Hour, Increment: time.Hour}},
+		{name: "cap:lots", wantErr: true},
+		{name: "cap:-1h", wantErr: true},
+		{name: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDistributor(tt.name, time.Hour, 8*time.Hour)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDistributor(%q) = %v, want error", tt.name, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDistributor(%q) error = %v", tt.name, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseDistributor(%q) = %#v, want %#v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// jiraAPIPath is the Jira Cloud REST API root below the site URL.
+const jiraAPIPath = "/rest/api/3"
+
+// issueKeyPattern matches Jira issue keys such as PROJ-123.
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[1-9][0-9]*$`)
+
+// IsIssueKey reports whether value looks like a Jira issue key such as PROJ-123.
+func IsIssueKey(value string) bool {
+	return issueKeyPattern.MatchString(value)
+}
+
+// JiraIssue identifies an issue by both the numeric ID Tempo uses and the key people use.
+type JiraIssue struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+	} `json:"fields"`
+}
+
+// JiraClient looks up issues on a Jira Cloud site. Construct one with NewJiraClient.
+type JiraClient struct {
+	client *Client
+}
+
+// NewJiraClient creates a client for the Jira site at siteURL (e.g. https://example.atlassian.net)
+// authenticated with an Atlassian account email and API token. Options set the transport and retries
+// as for NewClient; the site and credentials given here always win over them.
+func NewJiraClient(siteURL, email, apiToken string, opts ...Option) *JiraClient {
+	client := newClient(opts,
+		WithBaseURL(strings.TrimRight(siteURL, "/")+jiraAPIPath),
+		withService("Jira"),
+		withBasicAuth(email, apiToken),
+	)
+	return &JiraClient{client: client}
+}
+
+// GetIssue fetches an issue by numeric ID or by key.
+func (j *JiraClient) GetIssue(idOrKey string) (*JiraIssue, error) {
+	req, err := j.client.newRequest("GET", fmt.Sprintf("/issue/%s?fields=summary", url.PathEscape(idOrKey)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := j.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("Jira authentication failed: check the configured email and API token")
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("Jira issue %s does not exist or you do not have permission to see it", idOrKey)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Jira request for issue %s failed with HTTP %d: %s", idOrKey, resp.StatusCode, string(body))
+	}
+
+	var issue JiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &issue, nil
+}
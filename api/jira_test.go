@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsIssueKey(t *testing.T) {
+	tests := map[string]bool{
+		"PROJ-123": true,
+		"AB2_X-7":  true,
+		"proj-123": false,
+		"10001":    false,
+		"PROJ-0":   false,
+		"PROJ":     false,
+		"-12":      false,
+	}
+	for value, expected := range tests {
+		if got := IsIssueKey(value); got != expected {
+			t.Errorf("IsIssueKey(%q) = %v, want %v", value, got, expected)
+		}
+	}
+}
+
+func TestJiraClient_GetIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "me@example.com" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/3/issue/PROJ-123", "/rest/api/3/issue/10123":
+			if r.URL.Query().Get("fields") != "summary" {
+				t.Errorf("fields = %q, want summary", r.URL.Query().Get("fields"))
+			}
+			w.Write([]byte(`{"id":"10123","key":"PROJ-123","fields":{"summary":"Build the thing"}}`))
+		case "/rest/api/3/issue/PROJ-500":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("boom"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	jira := NewJiraClient(server.URL+"/", "me@example.com", "secret\n", WithMaxAttempts(1))
+
+	for _, ref := range []string{"PROJ-123", "10123"} {
+		issue, err := jira.GetIssue(ref)
+		if err != nil {
+			t.Fatalf("GetIssue(%s) error = %v", ref, err)
+		}
+		if issue.ID != "10123" || issue.Key != "PROJ-123" || issue.Fields.Summary != "Build the thing" {
+			t.Errorf("GetIssue(%s) = %+v", ref, issue)
+		}
+	}
+
+	if _, err := jira.GetIssue("PROJ-404"); err == nil {
+		t.Error("expected error for a missing issue")
+	}
+	if _, err := jira.GetIssue("PROJ-500"); err == nil {
+		t.Error("expected error for a server error")
+	}
+	if _, err := NewJiraClient(server.URL, "me@example.com", "wrong", WithMaxAttempts(1)).GetIssue("PROJ-123"); err == nil {
+		t.Error("expected error for bad credentials")
+	}
+}
+
+func TestNewJiraClient_SiteAndCredentialsWinOverOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Errorf("Authorization = %q, want basic auth", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"id":"10123","key":"PROJ-123"}`))
+	}))
+	defer server.Close()
+
+	jira := NewJiraClient(server.URL, "me@example.com", "secret", WithBaseURL("http://tempo.invalid"), WithMaxAttempts(1))
+	if _, err := jira.GetIssue("PROJ-123"); err != nil {
+		t.Fatalf("GetIssue() error = %v, want the request sent to the Jira site", err)
+	}
+	if jira.client.service != "Jira" {
+		t.Errorf("service = %q, want Jira", jira.client.service)
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// startTimeLayout is the format of a worklog's StartTime.
+const startTimeLayout = "15:04:05"
+
+// endOfDay is the latest a worklog may run to.
+const endOfDay = 24 * time.Hour
+
+// DayLayout gives each day's worklogs start times that run back to back without overlapping.
+type DayLayout struct {
+	// Start is when the first worklog of a day begins, as an offset from midnight.
+	Start time.Duration
+	// LunchStart and LunchLength reserve a break no worklog is placed in. A zero LunchLength means no break.
+	LunchStart  time.Duration
+	LunchLength time.Duration
+}
+
+// DefaultDayLayout starts every day at 09:00 with no lunch break.
+var DefaultDayLayout = DayLayout{Start: 9 * time.Hour}
+
+// interval is a busy stretch of a day, as offsets from midnight.
+type interval struct {
+	start, end time.Duration
+}
+
+// Layout sets the start time of every planned worklog so that, on each day, they follow one another from
+// Start while skipping the lunch break and any existing worklogs. A worklog that would run into a break or
+// an existing worklog is split around it. The returned worklogs keep the planned order.
+func (l DayLayout) Layout(planned []*WorklogRequest, existing []WorklogResponse) ([]*WorklogRequest, error) {
+	busy := map[string][]interval{}
+	for _, worklog := range existing {
+		start, err := parseStartTime(worklog.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("existing worklog %d: %w", worklog.TempoWorklogID, err)
+		}
+		end := start + time.Duration(worklog.TimeSpentSeconds)*time.Second
+		busy[worklog.StartDate] = append(busy[worklog.StartDate], interval{start, end})
+	}
+
+	cursors := map[string]time.Duration{}
+	var laidOut []*WorklogRequest
+	for _, req := range planned {
+		date := req.StartDate
+		if _, ok := cursors[date]; !ok {
+			cursors[date] = l.Start
+			if l.LunchLength > 0 {
+				busy[date] = append(busy[date], interval{l.LunchStart, l.LunchStart + l.LunchLength})
+			}
+			sort.Slice(busy[date], func(i, j int) bool { return busy[date][i].start < busy[date][j].start })
+		}
+
+		pieces, cursor, err := place(req, cursors[date], busy[date])
+		if err != nil {
+			return nil, err
+		}
+		cursors[date] = cursor
+		laidOut = append(laidOut, pieces...)
+	}
+	return laidOut, nil
+}
+
+// place lays req out from cursor into the gaps between the sorted busy intervals. It returns the pieces
+// req was split into and where the next worklog of the day may start.
+func place(req *WorklogRequest, cursor time.Duration, busy []interval) ([]*WorklogRequest, time.Duration, error) {
+	var pieces []*WorklogRequest
+	remaining := time.Duration(req.TimeSpentSeconds) * time.Second
+	for remaining > 0 {
+		gapEnd := endOfDay
+		for _, b := range busy {
+			if b.end <= cursor {
+				continue
+			}
+			if b.start <= cursor {
+				cursor = b.end
+				continue
+			}
+			gapEnd = b.start
+			break
+		}
+		if cursor >= endOfDay {
+			return nil, 0, fmt.Errorf("%s on %s does not fit into the day without overlapping other worklogs", FormatHours(int(remaining/time.Second)), req.StartDate)
+		}
+
+		length := min(remaining, gapEnd-cursor)
+		piece := *req
+		piece.StartTime = formatStartTime(cursor)
+		piece.TimeSpentSeconds = int(length / time.Second)
+		pieces = append(pieces, &piece)
+
+		cursor += length
+		remaining -= length
+	}
+	return pieces, cursor, nil
+}
+
+// parseStartTime turns a worklog start time such as "09:30:00" into an offset from midnight.
+func parseStartTime(value string) (time.Duration, error) {
+	clock, err := time.Parse(startTimeLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start time %q: %w", value, err)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute + time.Duration(clock.Second())*time.Second, nil
+}
+
+// formatStartTime turns an offset from midnight into a worklog start time.
+func formatStartTime(offset time.Duration) string {
+	seconds := int(offset / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayLayout(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	hours := func(h float64) int { return int(h * secondsPerHour) }
+
+	type placed struct {
+		date, start string
+		seconds     int
+	}
+
+	tests := []struct {
+		name     string
+		layout   DayLayout
+		planned  []*WorklogRequest
+		existing []WorklogResponse
+		expected []placed
+		wantErr  bool
+	}{
+		{
+			name:   "back to back from the day start",
+			layout: DefaultDayLayout,
+			planned: []*WorklogRequest{
+				createWorklogRequest(CapitalizableWorkType, hours(6), monday, "acct", "1"),
+				createWorklogRequest(OtherWorkType, hours(1.5), monday, "acct", "1"),
+				createWorklogRequest(PtoWorkType, hours(0.5), monday, "acct", "1"),
+				createWorklogRequest(CapitalizableWorkType, hours(8), tuesday, "acct", "1"),
+			},
+			expected: []placed{
+				{"2024-01-08", "09:00:00", hours(6)},
+				{"2024-01-08", "15:00:00", hours(1.5)},
+				{"2024-01-08", "16:30:00", hours(0.5)},
+				{"2024-01-09", "09:00:00", hours(8)},
+			},
+		},
+		{
+			name:   "split around lunch",
+			layout: DayLayout{Start: 8*time.Hour + 30*time.Minute, LunchStart: 12 * time.Hour, LunchLength: 45 * time.Minute},
+			planned: []*WorklogRequest{
+				createWorklogRequest(CapitalizableWorkType, hours(6), monday, "acct", "1"),
+				createWorklogRequest(OtherWorkType, hours(2), monday, "acct", "1"),
+			},
+			expected: []placed{
+				{"2024-01-08", "08:30:00", hours(3.5)},
+				{"2024-01-08", "12:45:00", hours(2.5)},
+				{"2024-01-08", "15:15:00", hours(2)},
+			},
+		},
+		{
+			name:   "skip existing worklogs",
+			layout: DefaultDayLayout,
+			planned: []*WorklogRequest{
+				createWorklogRequest(CapitalizableWorkType, hours(4), monday, "acct", "1"),
+			},
+			existing: []WorklogResponse{
+				{TempoWorklogID: 1, StartDate: "2024-01-08", StartTime: "08:00:00", TimeSpentSeconds: hours(1.5)},
+				{TempoWorklogID: 2, StartDate: "2024-01-08", StartTime: "10:00:00", TimeSpentSeconds: hours(1)},
+				{TempoWorklogID: 3, StartDate: "2024-01-09", StartTime: "09:00:00", TimeSpentSeconds: hours(8)},
+			},
+			expected: []placed{
+				{"2024-01-08", "09:30:00", hours(0.5)},
+				{"2024-01-08", "11:00:00", hours(3.5)},
+			},
+		},
+		{
+			name:    "day too full",
+			layout:  DayLayout{Start: 20 * time.Hour},
+			planned: []*WorklogRequest{createWorklogRequest(CapitalizableWorkType, hours(5), monday, "acct", "1")},
+			wantErr: true,
+		},
+		{
+			name:     "invalid existing start time",
+			layout:   DefaultDayLayout,
+			planned:  []*WorklogRequest{createWorklogRequest(CapitalizableWorkType, hours(1), monday, "acct", "1")},
+			existing: []WorklogResponse{{TempoWorklogID: 9, StartDate: "2024-01-08", StartTime: "9am", TimeSpentSeconds: 60}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.layout.Layout(tt.planned, tt.existing)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Layout() returned %d worklogs, want %d: %+v", len(got), len(tt.expected), got)
+			}
+			for i, want := range tt.expected {
+				if got[i].StartDate != want.date || got[i].StartTime != want.start || got[i].TimeSpentSeconds != want.seconds {
+					t.Errorf("worklog %d = %s %s %ds, want %s %s %ds", i, got[i].StartDate, got[i].StartTime, got[i].TimeSpentSeconds, want.date, want.start, want.seconds)
+				}
+			}
+		})
+	}
+}
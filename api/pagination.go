@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PageMetadata describes where a page of a Tempo list response sits in the full result set.
+type PageMetadata struct {
+	Count  int `json:"count"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	// Next is the URL of the following page, empty on the last one
+	Next string `json:"next"`
+}
+
+// Page is one page of a Tempo list response.
+type Page[T any] struct {
+	Results  []T          `json:"results"`
+	Metadata PageMetadata `json:"metadata"`
+}
+
+// paginate iterates over every result of the Tempo list endpoint at path, fetching pages as they are needed.
+// It follows metadata.next when Tempo provides it; for endpoints that never link pages it asks for the next
+// offset while pages come back full. Iteration stops after the first error, which is yielded with a zero result.
+func paginate[T any](c *Client, path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		base, err := url.Parse(c.baseURL)
+		if err != nil {
+			yield(zero, fmt.Errorf("invalid base URL %q: %w", c.baseURL, err))
+			return
+		}
+
+		seen := map[string]bool{}
+		linked := false
+		for pageURL := c.baseURL + path; pageURL != ""; {
+			if seen[pageURL] {
+				yield(zero, fmt.Errorf("pagination loop: %s was already fetched", pageURL))
+				return
+			}
+			seen[pageURL] = true
+
+			page, err := getPage[T](c, pageURL)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, result := range page.Results {
+				if !yield(result, nil) {
+					return
+				}
+			}
+			linked = linked || page.Metadata.Next != ""
+			if pageURL, err = nextPageURL(base, pageURL, page, linked); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// listAll collects every result of the Tempo list endpoint at path.
+func listAll[T any](c *Client, path string) ([]T, error) {
+	var results []T
+	for result, err := range paginate[T](c, path) {
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// nextPageURL returns the URL of the page after page, which was fetched from pageURL, or "" after the last page.
+// Once an endpoint has linked its pages, a page without a next link is the last one. A relative next link is
+// resolved against base, and the link must stay on base's host so the token is never sent anywhere else.
+func nextPageURL[T any](base *url.URL, pageURL string, page *Page[T], linked bool) (string, error) {
+	metadata := page.Metadata
+	if metadata.Next != "" {
+		next, err := url.Parse(metadata.Next)
+		if err != nil {
+			return "", fmt.Errorf("invalid next page link %q: %w", metadata.Next, err)
+		}
+		next = base.ResolveReference(next)
+		if next.Scheme != base.Scheme || next.Host != base.Host {
+			return "", fmt.Errorf("refusing to follow next page link %q: it is not on %s://%s", metadata.Next, base.Scheme, base.Host)
+		}
+		return next.String(), nil
+	}
+	if linked || metadata.Limit <= 0 || len(page.Results) < metadata.Limit {
+		return "", nil
+	}
+
+	next, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid page URL %q: %w", pageURL, err)
+	}
+	query := next.Query()
+	query.Set("offset", strconv.Itoa(metadata.Offset+len(page.Results)))
+	query.Set("limit", strconv.Itoa(metadata.Limit))
+	next.RawQuery = query.Encode()
+	return next.String(), nil
+}
+
+// getPage fetches one page of a list endpoint from its full URL.
+func getPage[T any](c *Client, pageURL string) (*Page[T], error) {
+	req, err := c.newRequestURL("GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, handleAPIError(resp, nil)
+	}
+
+	var page Page[T]
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &page, nil
+}
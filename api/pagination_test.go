@@ -0,0 +1,159 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    map[string]string
+		expected []int
+		wantErr  string
+	}{
+		{
+			name: "single page",
+			pages: map[string]string{
+				"": `{"metadata":{"count":2,"offset":0,"limit":50},"results":[1,2]}`,
+			},
+			expected: []int{1, 2},
+		},
+		{
+			name: "follows relative next links",
+			pages: map[string]string{
+				"":  `{"metadata":{"count":2,"offset":0,"limit":2,"next":"/items?offset=2&limit=2"},"results":[1,2]}`,
+				"2": `{"metadata":{"count":2,"offset":2,"limit":2},"results":[3,4]}`,
+			},
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name: "follows absolute next links",
+			pages: map[string]string{
+				"":  `{"metadata":{"count":1,"offset":0,"limit":1,"next":"{{server}}/items?offset=1&limit=1"},"results":[1]}`,
+				"1": `{"metadata":{"count":1,"offset":1,"limit":1,"next":"{{server}}/items?offset=2&limit=1"},"results":[2]}`,
+				"2": `{"metadata":{"count":0,"offset":2,"limit":1},"results":[]}`,
+			},
+			expected: []int{1, 2},
+		},
+		{
+			name: "asks for the next offset without next links",
+			pages: map[string]string{
+				"":  `{"metadata":{"count":2,"offset":0,"limit":2},"results":[1,2]}`,
+				"2": `{"metadata":{"count":2,"offset":2,"limit":2},"results":[3,4]}`,
+				"4": `{"metadata":{"count":1,"offset":4,"limit":2},"results":[5]}`,
+			},
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "next link loop",
+			pages: map[string]string{
+				"":  `{"metadata":{"count":1,"offset":0,"limit":1,"next":"/items?offset=1"},"results":[1]}`,
+				"1": `{"metadata":{"count":1,"offset":1,"limit":1,"next":"/items?offset=1"},"results":[2]}`,
+			},
+			wantErr: "pagination loop",
+		},
+		{
+			name: "next link on another host",
+			pages: map[string]string{
+				"": `{"metadata":{"count":1,"offset":0,"limit":1,"next":"https://attacker.example/items?offset=1"},"results":[1]}`,
+			},
+			wantErr: "refusing to follow",
+		},
+		{
+			name: "next link with another scheme",
+			pages: map[string]string{
+				"": `{"metadata":{"count":1,"offset":0,"limit":1,"next":"{{https}}/items?offset=1"},"results":[1]}`,
+			},
+			wantErr: "refusing to follow",
+		},
+		{
+			name: "failed page",
+			pages: map[string]string{
+				"": `{"metadata":{"count":1,"offset":0,"limit":1,"next":"/items?offset=1"},"results":[1]}`,
+			},
+			wantErr: "HTTP 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, ok := tt.pages[r.URL.Query().Get("offset")]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				page = strings.ReplaceAll(page, "{{server}}", server.URL)
+				w.Write([]byte(strings.ReplaceAll(page, "{{https}}", strings.Replace(server.URL, "http://", "https://", 1))))
+			}))
+			defer server.Close()
+
+			client := NewClient("test-token", WithBaseURL(server.URL))
+			results, err := listAll[int](client, "/items")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("listAll() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listAll() error = %v", err)
+			}
+			if fmt.Sprint(results) != fmt.Sprint(tt.expected) {
+				t.Errorf("listAll() = %v, want %v", results, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPaginate_RootRelativeNextLinkUnderBasePath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/4/items" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			w.Write([]byte(`{"metadata":{"count":1,"offset":0,"limit":1,"next":"/4/items?offset=1&limit=1"},"results":[1]}`))
+		case "1":
+			w.Write([]byte(`{"metadata":{"count":1,"offset":1,"limit":1},"results":[2]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL+"/4"))
+	results, err := listAll[int](client, "/items")
+	if err != nil {
+		t.Fatalf("listAll() error = %v", err)
+	}
+	if fmt.Sprint(results) != "[1 2]" {
+		t.Errorf("listAll() = %v, want [1 2]", results)
+	}
+}
+
+func TestPaginate_StopsEarly(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"metadata":{"count":2,"offset":0,"limit":2,"next":"/items?offset=2&limit=2"},"results":[1,2]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	for result, err := range paginate[int](client, "/items") {
+		if err != nil {
+			t.Fatalf("paginate() error = %v", err)
+		}
+		if result == 1 {
+			break
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+)
+
+// DefaultIncrement is the granularity hours are spread across days in when no increment is given.
+const DefaultIncrement = 15 * time.Minute
+
+// WorklogPlan describes one category of time to spread across a week.
+type WorklogPlan struct {
+	WorkType  WorkType
+	Seconds   int
+	StartDay  time.Time
+	AccountID string
+	IssueID   string
+	// Days are the dates the time may be logged on, in order. When nil, the working days of
+	// calendar.DefaultWeek from StartDay are used.
+	Days []time.Time
+	// Distributor spreads the time across days; nil means FrontLoaded in DefaultIncrement steps.
+	Distributor Distributor
+}
+
+// workingDays returns the dates a plan's time is spread across.
+func (p WorklogPlan) workingDays() []time.Time {
+	if p.Days != nil {
+		return p.Days
+	}
+	return calendar.DefaultWeek().WorkingDaysFrom(p.StartDay)
+}
+
+// PlanWorklog distributes a plan's time across its working days and builds the worklog requests without sending them.
+func PlanWorklog(plan WorklogPlan) ([]*WorklogRequest, error) {
+	distributor := plan.Distributor
+	if distributor == nil {
+		distributor = FrontLoaded{Increment: DefaultIncrement}
+	}
+
+	days := plan.workingDays()
+	perDay, err := distributor.Distribute(plan.Seconds, len(days))
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []*WorklogRequest
+	placed := 0
+	for day, seconds := range perDay {
+		if seconds == 0 {
+			continue
+		}
+		placed += seconds
+		planned = append(planned, createWorklogRequest(plan.WorkType, seconds, days[day], plan.AccountID, plan.IssueID))
+	}
+	if placed != max(plan.Seconds, 0) {
+		return nil, fmt.Errorf("only %s of %s could be placed on %d working day(s)", FormatHours(placed), FormatHours(plan.Seconds), len(days))
+	}
+	return planned, nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanWorklog(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	if planned, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, StartDay: monday}); err != nil || len(planned) != 0 {
+		t.Errorf("PlanWorklog with 0 seconds returned %d entries (err %v), want 0", len(planned), err)
+	}
+
+	planned, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: 23 * secondsPerHour, StartDay: monday, AccountID: "acct", IssueID: "10001"})
+	if err != nil {
+		t.Fatalf("PlanWorklog() error = %v", err)
+	}
+	if len(planned) != 5 {
+		t.Fatalf("got %d entries, want 5", len(planned))
+	}
+	total := 0
+	for _, req := range planned {
+		total += req.TimeSpentSeconds
+	}
+	if total != 23*secondsPerHour {
+		t.Errorf("total = %d seconds, want %d", total, 23*secondsPerHour)
+	}
+	if planned[4].StartDate != "2024-01-12" {
+		t.Errorf("last StartDate = %q, want %q", planned[4].StartDate, "2024-01-12")
+	}
+}
+
+func TestPlanWorklog_SkipsEmptyDays(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	planned, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: 5 * secondsPerHour, StartDay: monday, Distributor: FrontLoaded{Increment: 2 * time.Hour}})
+	if err != nil {
+		t.Fatalf("PlanWorklog() error = %v", err)
+	}
+	if len(planned) != 2 {
+		t.Fatalf("got %d entries, want 2", len(planned))
+	}
+	if planned[0].TimeSpentSeconds != 3*secondsPerHour || planned[1].TimeSpentSeconds != 2*secondsPerHour {
+		t.Errorf("got %d and %d seconds, want 3h and 2h", planned[0].TimeSpentSeconds, planned[1].TimeSpentSeconds)
+	}
+}
+
+func TestPlanWorklog_BackLoadedAndFillDays(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	planned, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: 3 * secondsPerHour, StartDay: monday, Distributor: BackLoaded{Increment: DefaultIncrement}})
+	if err != nil {
+		t.Fatalf("PlanWorklog() error = %v", err)
+	}
+	if len(planned) != 3 || planned[0].StartDate != "2024-01-10" || planned[2].StartDate != "2024-01-12" {
+		t.Errorf("back-loaded 3 hours should land Wednesday to Friday, got %d entries starting %s", len(planned), planned[0].StartDate)
+	}
+
+	planned, err = PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: 12 * secondsPerHour, StartDay: monday, Distributor: FillDays{DayLength: 8 * time.Hour}})
+	if err != nil {
+		t.Fatalf("PlanWorklog() error = %v", err)
+	}
+	if len(planned) != 2 || planned[0].TimeSpentSeconds != 8*secondsPerHour || planned[1].StartDate != "2024-01-09" {
+		t.Errorf("fill-days 12 hours should be 8h Monday and 4h Tuesday, got %+v", planned)
+	}
+
+	if _, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: 41 * secondsPerHour, StartDay: monday, Distributor: FillDays{DayLength: 8 * time.Hour}}); err == nil {
+		t.Error("expected error when fill-days overflows the week")
+	}
+}
+
+func TestPlanWorklog_ExplicitDays(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	days := []time.Time{monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 3)}
+
+	planned, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: 16 * secondsPerHour, StartDay: monday, Days: days})
+	if err != nil {
+		t.Fatalf("PlanWorklog() error = %v", err)
+	}
+	if len(planned) != 2 || planned[0].StartDate != "2024-01-09" || planned[1].StartDate != "2024-01-11" {
+		t.Errorf("expected Tuesday and Thursday only, got %+v", planned)
+	}
+
+	if _, err := PlanWorklog(WorklogPlan{WorkType: PtoWorkType, Seconds: secondsPerHour, StartDay: monday, Days: []time.Time{}}); err == nil {
+		t.Error("expected error when there are no working days to log on")
+	}
+}
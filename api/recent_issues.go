@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// RecentIssue is an issue the user has recently logged time against.
+type RecentIssue struct {
+	IssueID  int
+	Seconds  int
+	Worklogs int
+	// LastLogged is the latest date time was logged on, as YYYY-MM-DD
+	LastLogged string
+}
+
+// GetRecentIssues returns the distinct issues in the user's worklogs updated since the given date, ranked by
+// the time logged against them. Every page of results is read.
+func (c *Client) GetRecentIssues(accountID string, since time.Time) ([]RecentIssue, error) {
+	query := url.Values{}
+	query.Set("updatedFrom", since.Format(time.DateOnly))
+	path := fmt.Sprintf("%s/%s?%s", userWorklogsPath, url.PathEscape(accountID), query.Encode())
+
+	worklogs, err := listAll[WorklogResponse](c, path)
+	if err != nil {
+		return nil, err
+	}
+	return rankIssues(worklogs), nil
+}
+
+// rankIssues totals worklogs by issue, most time first. Ties go to the issue seen later in the results.
+func rankIssues(worklogs []WorklogResponse) []RecentIssue {
+	byIssue := map[int]*RecentIssue{}
+	lastSeen := map[int]int{}
+	for i, worklog := range worklogs {
+		issue, ok := byIssue[worklog.Issue.ID]
+		if !ok {
+			issue = &RecentIssue{IssueID: worklog.Issue.ID}
+			byIssue[worklog.Issue.ID] = issue
+		}
+		issue.Seconds += worklog.TimeSpentSeconds
+		issue.Worklogs++
+		if worklog.StartDate > issue.LastLogged {
+			issue.LastLogged = worklog.StartDate
+		}
+		lastSeen[worklog.Issue.ID] = i
+	}
+
+	ranked := make([]RecentIssue, 0, len(byIssue))
+	for _, issue := range byIssue {
+		ranked = append(ranked, *issue)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Seconds != ranked[j].Seconds {
+			return ranked[i].Seconds > ranked[j].Seconds
+		}
+		return lastSeen[ranked[i].IssueID] > lastSeen[ranked[j].IssueID]
+	})
+	return ranked
+}
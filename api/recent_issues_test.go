@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetRecentIssues_FollowsPagesAndRanks(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/worklogs/user/acct-123" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/worklogs/user/acct-123")
+		}
+		if r.URL.Query().Get("updatedFrom") != "2026-10-01" {
+			t.Errorf("updatedFrom = %q, want 2026-10-01", r.URL.Query().Get("updatedFrom"))
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprintf(w, `{"metadata":{"count":2,"offset":0,"limit":2,"next":"%s/worklogs/user/acct-123?updatedFrom=2026-10-01&offset=2&limit=2"},
+				"results":[{"issue":{"id":1},"startDate":"2026-10-05","timeSpentSeconds":3600},{"issue":{"id":2},"startDate":"2026-10-05","timeSpentSeconds":7200}]}`, server.URL)
+		case "2":
+			w.Write([]byte(`{"metadata":{"count":2,"offset":2,"limit":2},
+				"results":[{"issue":{"id":1},"startDate":"2026-10-07","timeSpentSeconds":7200},{"issue":{"id":3},"startDate":"2026-10-08","timeSpentSeconds":1800}]}`))
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	issues, err := client.GetRecentIssues("acct-123", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetRecentIssues() error = %v", err)
+	}
+
+	expected := []RecentIssue{
+		{IssueID: 1, Seconds: 10800, Worklogs: 2, LastLogged: "2026-10-07"},
+		{IssueID: 2, Seconds: 7200, Worklogs: 1, LastLogged: "2026-10-05"},
+		{IssueID: 3, Seconds: 1800, Worklogs: 1, LastLogged: "2026-10-08"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("GetRecentIssues() = %+v, want %+v", issues, expected)
+	}
+	for i := range expected {
+		if issues[i] != expected[i] {
+			t.Errorf("issue %d = %+v, want %+v", i, issues[i], expected[i])
+		}
+	}
+}
+
+func TestGetRecentIssues_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	if _, err := client.GetRecentIssues("acct-123", time.Now()); err == nil {
+		t.Fatal("expected error for a forbidden request")
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed Tempo requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles on every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps both the exponential backoff and any Retry-After the server asks for.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when a client is not given one explicitly.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// WithRetryPolicy sets the retry policy used for every request.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithMaxAttempts overrides only the attempt budget of the retry policy.
+func WithMaxAttempts(attempts int) Option {
+	return func(c *Client) {
+		if attempts > 0 {
+			c.retry.MaxAttempts = attempts
+		}
+	}
+}
+
+// shouldRetry reports whether a request may be sent again after the given outcome.
+// Idempotent requests are retried on throttling, gateway errors and network failures.
+// POST requests create worklogs, so they are only retried when Tempo certainly did not
+// process them: a 429 rejection, or a connection that was never established. A 503 may
+// come from a proxy in front of Tempo after the worklog was created, so it is not enough.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+
+	if err != nil {
+		return idempotent || isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// isDialError reports whether err happened before the request reached the server.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After header on
+// a 429 or 503 takes precedence over the exponential backoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return p.cap(delay)
+		}
+	}
+
+	ceiling := p.cap(p.BaseDelay << (attempt - 1))
+	if ceiling <= 0 {
+		return 0
+	}
+	// Full jitter spreads out concurrent clients hitting the same rate limit
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// cap limits a delay to MaxDelay, treating overflow from large shifts as the maximum.
+func (p RetryPolicy) cap(delay time.Duration) time.Duration {
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay < 0) {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: delay seconds and an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// drainAndClose discards a response that is about to be retried so the connection can be reused.
+func drainAndClose(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client pointed at url that records sleeps instead of waiting.
+func newTestClient(url string, sleeps *[]time.Duration, opts ...Option) *Client {
+	client := NewClient("test-token", append([]Option{WithBaseURL(url)}, opts...)...)
+	client.sleep = func(d time.Duration) {
+		*sleeps = append(*sleeps, d)
+	}
+	return client
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		statusCode int
+		expected   bool
+	}{
+		{name: "GET 429", method: http.MethodGet, statusCode: http.StatusTooManyRequests, expected: true},
+		{name: "GET 502", method: http.MethodGet, statusCode: http.StatusBadGateway, expected: true},
+		{name: "GET 503", method: http.MethodGet, statusCode: http.StatusServiceUnavailable, expected: true},
+		{name: "GET 504", method: http.MethodGet, statusCode: http.StatusGatewayTimeout, expected: true},
+		{name: "GET 500", method: http.MethodGet, statusCode: http.StatusInternalServerError, expected: false},
+		{name: "GET 400", method: http.MethodGet, statusCode: http.StatusBadRequest, expected: false},
+		{name: "POST 429", method: http.MethodPost, statusCode: http.StatusTooManyRequests, expected: true},
+		{name: "POST 503 may have been processed", method: http.MethodPost, statusCode: http.StatusServiceUnavailable, expected: false},
+		{name: "PATCH 503 may have been processed", method: http.MethodPatch, statusCode: http.StatusServiceUnavailable, expected: false},
+		{name: "POST 502 may have been processed", method: http.MethodPost, statusCode: http.StatusBadGateway, expected: false},
+		{name: "POST 504 may have been processed", method: http.MethodPost, statusCode: http.StatusGatewayTimeout, expected: false},
+		{name: "DELETE 504", method: http.MethodDelete, statusCode: http.StatusGatewayTimeout, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shouldRetry(tt.method, &http.Response{StatusCode: tt.statusCode}, nil)
+			if got != tt.expected {
+				t.Errorf("shouldRetry(%s, %d) = %v, want %v", tt.method, tt.statusCode, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "7", expected: 7 * time.Second, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "http date", value: "Mon, 08 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "http date in the past", value: "Mon, 08 Jan 2024 11:00:00 GMT", expected: 0, ok: true},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		delay := policy.backoff(attempt, nil)
+		if delay < 0 || delay > policy.MaxDelay {
+			t.Errorf("backoff(%d) = %v, want within [0, %v]", attempt, delay, policy.MaxDelay)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if got := policy.backoff(1, resp); got != policy.MaxDelay {
+		t.Errorf("backoff with long Retry-After = %v, want capped at %v", got, policy.MaxDelay)
+	}
+}
+
+func TestClientDo_RetriesThrottledPostWithRetryAfter(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"tempoWorklogId":1}`))
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	client := newTestClient(server.URL, &sleeps)
+	reqBody := createWorklogRequest(CapitalizableWorkType, 8*secondsPerHour, time.Now(), "acct-123", "10001")
+	if _, err := client.sendWorklogEntry(reqBody); err != nil {
+		t.Fatalf("sendWorklogEntry() error = %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(bodies))
+	}
+	if bodies[0] != bodies[1] || !strings.Contains(bodies[1], "10001") {
+		t.Errorf("retried request body was not replayed: %q vs %q", bodies[0], bodies[1])
+	}
+	if len(sleeps) != 1 || sleeps[0] != 2*time.Second {
+		t.Errorf("sleeps = %v, want [2s]", sleeps)
+	}
+}
+
+func TestClientDo_DoesNotRetryPostOnGatewayError(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	client := newTestClient(server.URL, &sleeps)
+	reqBody := createWorklogRequest(CapitalizableWorkType, 8*secondsPerHour, time.Now(), "acct-123", "10001")
+	if _, err := client.sendWorklogEntry(reqBody); err == nil {
+		t.Fatal("expected error for 502 response")
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1", requests)
+	}
+}
+
+func TestClientDo_GetStopsAtAttemptBudget(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	client := newTestClient(server.URL, &sleeps, WithMaxAttempts(3))
+	if _, err := client.GetRecentIssues("acct-123", time.Now()); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if requests != 3 {
+		t.Errorf("server received %d requests, want 3", requests)
+	}
+	if len(sleeps) != 2 {
+		t.Errorf("slept %d times, want 2", len(sleeps))
+	}
+}
+
+func TestClientDo_RetriesPostWhenConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var sleeps []time.Duration
+	client := newTestClient(url, &sleeps, WithMaxAttempts(2))
+	reqBody := createWorklogRequest(CapitalizableWorkType, 8*secondsPerHour, time.Now(), "acct-123", "10001")
+	if _, err := client.sendWorklogEntry(reqBody); err == nil {
+		t.Fatal("expected error when server is unreachable")
+	}
+	if len(sleeps) != 1 {
+		t.Errorf("slept %d times, want 1 retry for a refused connection", len(sleeps))
+	}
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const userSchedulePath = "/user-schedule"
+
+// WorkingDayType is the schedule day type for a day the user is expected to work.
+const WorkingDayType = "WORKING_DAY"
+
+// ScheduleDay is one day of a user's Tempo work schedule.
+type ScheduleDay struct {
+	Date            string `json:"date"`
+	RequiredSeconds int    `json:"requiredSeconds"`
+	Type            string `json:"type"`
+}
+
+// IsWorkingDay reports whether the user is expected to log time on this day.
+// Holidays and non-working days from the Tempo schedule are not working days.
+func (d ScheduleDay) IsWorkingDay() bool {
+	return d.Type == WorkingDayType && d.RequiredSeconds > 0
+}
+
+// UserScheduleResponse represents one page of the response from the user schedule endpoint.
+type UserScheduleResponse = Page[ScheduleDay]
+
+// GetUserSchedule returns a user's schedule for each day from from to to, inclusive.
+func (c *Client) GetUserSchedule(accountID string, from, to time.Time) ([]ScheduleDay, error) {
+	query := url.Values{}
+	query.Set("from", from.Format(time.DateOnly))
+	query.Set("to", to.Format(time.DateOnly))
+	path := fmt.Sprintf("%s/%s?%s", userSchedulePath, url.PathEscape(accountID), query.Encode())
+	return listAll[ScheduleDay](c, path)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetUserSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user-schedule/acct-123" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/user-schedule/acct-123")
+		}
+		if got := r.URL.Query().Get("from"); got != "2024-01-08" {
+			t.Errorf("from = %q, want %q", got, "2024-01-08")
+		}
+		w.Write([]byte(`{"results":[
+			{"date":"2024-01-08","requiredSeconds":36000,"type":"WORKING_DAY"},
+			{"date":"2024-01-09","requiredSeconds":0,"type":"HOLIDAY"},
+			{"date":"2024-01-13","requiredSeconds":0,"type":"NON_WORKING_DAY"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	days, err := client.GetUserSchedule("acct-123", monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("GetUserSchedule() error = %v", err)
+	}
+	if len(days) != 3 {
+		t.Fatalf("got %d days, want 3", len(days))
+	}
+	if !days[0].IsWorkingDay() || days[1].IsWorkingDay() || days[2].IsWorkingDay() {
+		t.Errorf("IsWorkingDay() should only be true for the working day: %+v", days)
+	}
+	if days[0].RequiredSeconds != 36000 {
+		t.Errorf("RequiredSeconds = %d, want 36000", days[0].RequiredSeconds)
+	}
+}
@@ -6,20 +6,20 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"net/http"
-	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
-	tempoAPIBaseURL     = "https://api.tempo.io/4/worklogs"
-	tempoAPIUserBaseURL = "https://api.tempo.io/4/worklogs/user"
-	defaultStartTime    = "09:00:00"
-	secondsPerHour      = 3600
-	maxDaysPerWeek      = 5
-	daysInWeek          = 7
+	worklogsPath     = "/worklogs"
+	userWorklogsPath = "/worklogs/user"
+	defaultStartTime = "09:00:00"
+	secondsPerHour   = 3600
+
+	// WorkTypeAttributeKey is the Tempo work attribute that classifies a worklog.
+	WorkTypeAttributeKey = "_WorkType_"
 )
 
 type WorkType struct {
@@ -42,60 +42,101 @@ type Issue struct {
 	ID int `json:"id"`
 }
 
+// WorklogAttributes holds the work attribute values attached to a worklog response.
+type WorklogAttributes struct {
+	Values []WorkType `json:"values"`
+}
+
 // WorklogResponse represents a worklog entry returned from the Tempo API.
 type WorklogResponse struct {
-	Issue Issue `json:"issue"`
+	TempoWorklogID   int               `json:"tempoWorklogId"`
+	Issue            Issue             `json:"issue"`
+	StartDate        string            `json:"startDate"`
+	StartTime        string            `json:"startTime"`
+	TimeSpentSeconds int               `json:"timeSpentSeconds"`
+	Description      string            `json:"description"`
+	Attributes       WorklogAttributes `json:"attributes"`
 }
 
-// UserWorklogsResponse represents the response from the user worklogs endpoint.
-type UserWorklogsResponse struct {
-	Results  []WorklogResponse `json:"results"`
-	Metadata struct {
-		Count  int `json:"count"`
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-	} `json:"metadata"`
+// AttributeValue returns the value of the work attribute with the given key, or "" if it is not set.
+func (w WorklogResponse) AttributeValue(key string) string {
+	for _, attr := range w.Attributes.Values {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return ""
 }
 
+// UserWorklogsResponse represents one page of the response from the user worklogs endpoint.
+type UserWorklogsResponse = Page[WorklogResponse]
+
 var (
 	CapitalizableWorkType = WorkType{
-		Key:   "_WorkType_",
+		Key:   WorkTypeAttributeKey,
 		Value: "14C",
 	}
 	PtoWorkType = WorkType{
-		Key:   "_WorkType_",
+		Key:   WorkTypeAttributeKey,
 		Value: "20E",
 	}
 	OtherWorkType = WorkType{
-		Key:   "_WorkType_",
+		Key:   WorkTypeAttributeKey,
 		Value: "12E",
 	}
 )
 
-// calculateHoursPerDay distributes total hours across up to 5 work days.
-// For hours < 5, it logs 1 hour per day.
-// For hours >= 5, it distributes hours across 5 days using the original formula:
-// hours/5 + (hours%5 + 5 - dayNumber)/5
-func calculateHoursPerDay(totalHours, dayNumber int) int {
-	if totalHours < maxDaysPerWeek {
-		return 1
+// distributeSeconds spreads a total across up to days work days in multiples of increment.
+// Totals under an hour per day are logged an hour a day, with the last day taking what is left.
+// Larger totals are split with splitIncrements.
+func distributeSeconds(totalSeconds, days int, increment time.Duration) []int {
+	if totalSeconds <= 0 || days <= 0 {
+		return nil
+	}
+
+	if totalSeconds < days*secondsPerHour {
+		var perDay []int
+		for remaining := totalSeconds; remaining > 0; remaining -= secondsPerHour {
+			perDay = append(perDay, min(remaining, secondsPerHour))
+		}
+		return perDay
 	}
-	baseHours := totalHours / maxDaysPerWeek
-	remainder := int(math.Mod(float64(totalHours), float64(maxDaysPerWeek)))
-	// Distribute remainder hours across days, with earlier days getting more
-	bonusHours := (remainder + maxDaysPerWeek - dayNumber) / maxDaysPerWeek
-	return baseHours + bonusHours
+
+	return splitIncrements(totalSeconds, days, increment)
+}
+
+// splitIncrements splits a total evenly across days in whole increments, with earlier days
+// taking the extra increments. Any time smaller than one increment is added to the first day
+// so the sum is exact.
+func splitIncrements(totalSeconds, days int, increment time.Duration) []int {
+	step := int(increment / time.Second)
+	if step <= 0 {
+		step = int(DefaultIncrement / time.Second)
+	}
+	units := totalSeconds / step
+	leftover := totalSeconds % step
+
+	perDay := make([]int, days)
+	for day := 1; day <= days; day++ {
+		baseUnits := units / days
+		// Distribute remainder units across days, with earlier days getting more
+		bonusUnits := (units%days + days - day) / days
+		perDay[day-1] = (baseUnits + bonusUnits) * step
+	}
+	perDay[0] += leftover
+	return perDay
 }
 
 // createWorklogRequest builds a worklog request for a specific day.
-func createWorklogRequest(workType WorkType, hours int, date time.Time, accountID, issueID string) *WorklogRequest {
+// The start date is date's calendar date in date's own zone, so date should already be in the user's zone.
+func createWorklogRequest(workType WorkType, seconds int, date time.Time, accountID, issueID string) *WorklogRequest {
 	return &WorklogRequest{
 		AuthorAccountID:  accountID,
 		Description:      "devctl tempo",
 		IssueID:          issueID,
 		StartDate:        date.Format(time.DateOnly),
 		StartTime:        defaultStartTime,
-		TimeSpentSeconds: hours * secondsPerHour,
+		TimeSpentSeconds: seconds,
 		Attributes:       []WorkType{workType},
 	}
 }
@@ -105,34 +146,69 @@ func cleanBearerToken(token string) string {
 	return strings.TrimSpace(token)
 }
 
-// sendWorklogEntry sends a single worklog entry to the Tempo API.
-func sendWorklogEntry(reqBody *WorklogRequest, bearerToken string) error {
+// sendWorklogEntry sends a single worklog entry to the Tempo API and returns the created worklog.
+func (c *Client) sendWorklogEntry(reqBody *WorklogRequest) (*WorklogResponse, error) {
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", tempoAPIBaseURL, bytes.NewBuffer(jsonData))
+	httpReq, err := c.newRequest("POST", worklogsPath, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, err
 	}
 
-	cleanedToken := cleanBearerToken(bearerToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cleanedToken))
-
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
-		return fmt.Errorf("failed to send HTTP request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return handleAPIError(resp, reqBody)
+		return nil, handleAPIError(resp, reqBody)
+	}
+
+	var created WorklogResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, &UnknownWorklogError{Request: reqBody, Err: fmt.Errorf("the response could not be decoded: %w", err)}
 	}
+	if created.TempoWorklogID == 0 {
+		return nil, &UnknownWorklogError{Request: reqBody, Err: fmt.Errorf("the response has no worklog ID")}
+	}
+	return &created, nil
+}
 
+// UnknownWorklogError is returned when Tempo created a worklog but its ID could not be read from the
+// response. The worklog exists, so it has to be deleted by hand if the submission is rolled back.
+type UnknownWorklogError struct {
+	Request *WorklogRequest
+	Err     error
+}
+
+func (e *UnknownWorklogError) Error() string {
+	return fmt.Sprintf("worklog was created but its ID is unknown: %v", e.Err)
+}
+
+func (e *UnknownWorklogError) Unwrap() error {
+	return e.Err
+}
+
+// DeleteWorklog removes a worklog by its Tempo worklog ID.
+func (c *Client) DeleteWorklog(worklogID int) error {
+	httpReq, err := c.newRequest("DELETE", fmt.Sprintf("%s/%d", worklogsPath, worklogID), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return handleAPIError(resp, nil)
+	}
 	return nil
 }
 
@@ -153,15 +229,19 @@ func handleAPIError(resp *http.Response, reqBody *WorklogRequest) error {
 	// Log detailed error information for debugging
 	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 		log.Printf("❌ API Request Failed (HTTP %d)\n", resp.StatusCode)
-		log.Printf("👤 Account ID: %s\n", reqBody.AuthorAccountID)
-		log.Printf("🎫 Issue ID: %s\n", reqBody.IssueID)
-		if len(reqBody.Attributes) > 0 {
-			log.Printf("🏷️ Work Type: %s\n", reqBody.Attributes[0].Value)
+		if reqBody != nil {
+			log.Printf("👤 Account ID: %s\n", reqBody.AuthorAccountID)
+			log.Printf("🎫 Issue ID: %s\n", reqBody.IssueID)
+			if len(reqBody.Attributes) > 0 {
+				log.Printf("🏷️ Work Type: %s\n", reqBody.Attributes[0].Value)
+			}
 		}
 		log.Printf("📝 Response: %s\n", string(bodyBytes))
 
-		reqJSON, _ := json.Marshal(reqBody)
-		log.Printf("🔗 Request: %s\n", string(reqJSON))
+		if reqBody != nil {
+			reqJSON, _ := json.Marshal(reqBody)
+			log.Printf("🔗 Request: %s\n", string(reqJSON))
+		}
 
 		return fmt.Errorf("API request failed with HTTP %d: %s", resp.StatusCode, string(bodyBytes))
 	}
@@ -171,114 +251,37 @@ func handleAPIError(resp *http.Response, reqBody *WorklogRequest) error {
 	return fmt.Errorf("server error (HTTP %d): %s", resp.StatusCode, string(bodyBytes))
 }
 
-// SendWorklog distributes hours across work days and sends worklog entries to Tempo.
-// It splits the total hours across up to 5 days (Monday-Friday) of the week starting from the given day.
-func SendWorklog(workType WorkType, hours int, startDay time.Time, bearerToken, accountID, issueID string) error {
-	if hours <= 0 {
-		return nil // No work to log
-	}
-
-	daysToLog := hours
-	if daysToLog > maxDaysPerWeek {
-		daysToLog = maxDaysPerWeek
-	}
-
-	for day := 1; day <= daysToLog; day++ {
-		hoursForDay := calculateHoursPerDay(hours, day)
-		logDate := startDay.AddDate(0, 0, day-1)
-
-		fmt.Printf("Logging %d hours for %s\n", hoursForDay, logDate.Format(time.DateOnly))
+// SubmitWorklogs sends planned worklog requests to Tempo in order, writing progress to progress.
+// The worklogs created before any failure are always returned so callers can roll them back.
+func (c *Client) SubmitWorklogs(planned []*WorklogRequest, progress io.Writer) ([]WorklogResponse, error) {
+	var created []WorklogResponse
+	for _, reqBody := range planned {
+		fmt.Fprintf(progress, "Logging %s for %s\n", FormatHours(reqBody.TimeSpentSeconds), reqBody.StartDate)
 
-		reqBody := createWorklogRequest(workType, hoursForDay, logDate, accountID, issueID)
-		if err := sendWorklogEntry(reqBody, bearerToken); err != nil {
-			return fmt.Errorf("failed to send worklog for %s: %w", logDate.Format(time.DateOnly), err)
+		worklog, err := c.sendWorklogEntry(reqBody)
+		if err != nil {
+			return created, fmt.Errorf("failed to send worklog for %s: %w", reqBody.StartDate, err)
 		}
+		created = append(created, *worklog)
 	}
 
-	return nil
-}
-
-// calculateWeekPriorDate calculates the date that is two weeks prior to the current date.
-// Returns the date formatted as YYYY-MM-DD.
-func calculateWeekPriorDate() string {
-	twoWeeksAgo := time.Now().AddDate(0, 0, -daysInWeek*2)
-	return twoWeeksAgo.Format(time.DateOnly)
+	return created, nil
 }
 
-// GetRecentIssueId fetches worklogs for a specific user account from the Tempo API.
-// It queries worklogs updated from two weeks prior to the current date.
-// Returns the issue ID from the last worklog entry in the results, or an error if the request fails or no results are found.
-func GetRecentIssueId(accountID, bearerToken string) (int, error) {
-	updatedFrom := calculateWeekPriorDate()
-
-	// Build URL with account ID and query parameter
-	baseURL := fmt.Sprintf("%s/%s", tempoAPIUserBaseURL, accountID)
-	apiURL, err := url.Parse(baseURL)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	// Add query parameter
-	query := apiURL.Query()
-	query.Set("updatedFrom", updatedFrom)
-	apiURL.RawQuery = query.Encode()
-
-	// Create HTTP request
-	req, err := http.NewRequest("GET", apiURL.String(), nil)
+// SendWorklog plans and submits a single category of time, writing progress to progress.
+func (c *Client) SendWorklog(plan WorklogPlan, progress io.Writer) ([]WorklogResponse, error) {
+	planned, err := PlanWorklog(plan)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	cleanedToken := cleanBearerToken(bearerToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", cleanedToken))
-
-	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("failed to send HTTP request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Handle non-OK responses
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return 0, fmt.Errorf("HTTP %d error (unable to read response body): %w", resp.StatusCode, err)
-		}
-
-		// Handle 401 Unauthorized - suggest reconfiguring API token
-		if resp.StatusCode == http.StatusUnauthorized {
-			log.Printf("❌ Authentication Failed (HTTP 401)\n")
-			log.Printf("🔑 Your Tempo API token appears to be invalid or expired.\n")
-			log.Printf("💡 Please configure a new Tempo API token by running:\n")
-			log.Printf("   tempo configure --token <YOUR_NEW_TOKEN>\n")
-			log.Printf("   or\n")
-			log.Printf("   devctl tempo configure --token <YOUR_NEW_TOKEN>\n")
-			return 0, fmt.Errorf("authentication failed: please configure a new Tempo API token")
-		}
-
-		log.Printf("❌ GetRecentIssueId API Request Failed (HTTP %d)\n", resp.StatusCode)
-		log.Printf("👤 Account ID: %s\n", accountID)
-		log.Printf("📅 Updated From: %s\n", updatedFrom)
-		log.Printf("📝 Response: %s\n", string(bodyBytes))
-
-		return 0, fmt.Errorf("API request failed with HTTP %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	// Decode response
-	var worklogsResponse UserWorklogsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&worklogsResponse); err != nil {
-		return 0, fmt.Errorf("failed to decode response: %w", err)
+		return nil, err
 	}
+	return c.SubmitWorklogs(planned, progress)
+}
 
-	// Extract issue ID from the last result
-	if len(worklogsResponse.Results) == 0 {
-		return 0, fmt.Errorf("no worklogs found in response")
+// FormatHours renders a number of seconds as hours for display, e.g. "8 hours".
+func FormatHours(seconds int) string {
+	hours := float64(seconds) / secondsPerHour
+	if hours == 1 {
+		return "1 hour"
 	}
-
-	lastResult := worklogsResponse.Results[len(worklogsResponse.Results)-1]
-	return lastResult.Issue.ID, nil
+	return strconv.FormatFloat(hours, 'f', -1, 64) + " hours"
 }
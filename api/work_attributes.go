@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	workAttributesPath = "/work-attributes"
+
+	// StaticListAttributeType is the work attribute type whose values come from a fixed list.
+	StaticListAttributeType = "STATIC_LIST"
+)
+
+// WorkAttribute describes a Tempo work attribute and, for static lists, its allowed values.
+type WorkAttribute struct {
+	Key      string            `json:"key"`
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Required bool              `json:"required"`
+	Values   []string          `json:"values"`
+	Names    map[string]string `json:"names"`
+}
+
+// ValueName returns the display name of a static list value, falling back to the value itself.
+func (a WorkAttribute) ValueName(value string) string {
+	if name, ok := a.Names[value]; ok && name != "" {
+		return name
+	}
+	return value
+}
+
+// HasValue reports whether value is one of the attribute's allowed values.
+func (a WorkAttribute) HasValue(value string) bool {
+	for _, v := range a.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWorkAttribute fetches a single work attribute definition by key, e.g. WorkTypeAttributeKey.
+func (c *Client) GetWorkAttribute(key string) (*WorkAttribute, error) {
+	req, err := c.newRequest("GET", fmt.Sprintf("%s/%s", workAttributesPath, url.PathEscape(key)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, handleAPIError(resp, nil)
+	}
+
+	var attribute WorkAttribute
+	if err := json.NewDecoder(resp.Body).Decode(&attribute); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &attribute, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetWorkAttribute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/work-attributes/_WorkType_" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/work-attributes/_WorkType_")
+		}
+		w.Write([]byte(`{"key":"_WorkType_","name":"Work Type","type":"STATIC_LIST","required":true,
+			"values":["14C","20E","12E"],"names":{"14C":"Capitalizable","20E":"PTO"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	attribute, err := client.GetWorkAttribute(WorkTypeAttributeKey)
+	if err != nil {
+		t.Fatalf("GetWorkAttribute() error = %v", err)
+	}
+	if attribute.Type != StaticListAttributeType {
+		t.Errorf("Type = %q, want %q", attribute.Type, StaticListAttributeType)
+	}
+	if len(attribute.Values) != 3 || !attribute.HasValue("12E") || attribute.HasValue("99X") {
+		t.Errorf("Values = %v, want 14C, 20E and 12E", attribute.Values)
+	}
+	if got := attribute.ValueName("14C"); got != "Capitalizable" {
+		t.Errorf("ValueName(14C) = %q, want %q", got, "Capitalizable")
+	}
+	if got := attribute.ValueName("12E"); got != "12E" {
+		t.Errorf("ValueName(12E) = %q, want fallback to value", got)
+	}
+}
+
+func TestGetWorkAttribute_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	if _, err := client.GetWorkAttribute("_Missing_"); err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// userWorklogsPageLimit is how many worklogs are asked for per page; further pages are followed.
+const userWorklogsPageLimit = 1000
+
+// GetUserWorklogs returns the worklogs a user logged between from and to, inclusive.
+func (c *Client) GetUserWorklogs(accountID string, from, to time.Time) ([]WorklogResponse, error) {
+	query := url.Values{}
+	query.Set("from", from.Format(time.DateOnly))
+	query.Set("to", to.Format(time.DateOnly))
+	query.Set("limit", fmt.Sprint(userWorklogsPageLimit))
+	path := fmt.Sprintf("%s/%s?%s", userWorklogsPath, url.PathEscape(accountID), query.Encode())
+	return listAll[WorklogResponse](c, path)
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetUserWorklogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/worklogs/user/acct-123" {
+			t.Errorf("Path = %q, want %q", r.URL.Path, "/worklogs/user/acct-123")
+		}
+		if got := r.URL.Query().Get("from"); got != "2024-01-08" {
+			t.Errorf("from = %q, want %q", got, "2024-01-08")
+		}
+		if got := r.URL.Query().Get("to"); got != "2024-01-14" {
+			t.Errorf("to = %q, want %q", got, "2024-01-14")
+		}
+		w.Write([]byte(`{"results":[{"tempoWorklogId":9,"issue":{"id":10001},"startDate":"2024-01-08","timeSpentSeconds":28800,
+			"attributes":{"values":[{"key":"_WorkType_","value":"14C"}]}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	worklogs, err := client.GetUserWorklogs("acct-123", monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("GetUserWorklogs() error = %v", err)
+	}
+	if len(worklogs) != 1 {
+		t.Fatalf("got %d worklogs, want 1", len(worklogs))
+	}
+	if got := worklogs[0].AttributeValue(WorkTypeAttributeKey); got != "14C" {
+		t.Errorf("AttributeValue() = %q, want %q", got, "14C")
+	}
+	if got := worklogs[0].AttributeValue("_Missing_"); got != "" {
+		t.Errorf("AttributeValue() for missing key = %q, want empty", got)
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	tests := map[int]string{
+		3600:  "1 hour",
+		28800: "8 hours",
+		27000: "7.5 hours",
+		0:     "0 hours",
+	}
+	for seconds, want := range tests {
+		if got := FormatHours(seconds); got != want {
+			t.Errorf("FormatHours(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestGetUserWorklogs_FollowsPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got == "" {
+			t.Errorf("limit was not set")
+		}
+		switch r.URL.Query().Get("offset") {
+		case "":
+			fmt.Fprintf(w, `{"metadata":{"count":1,"offset":0,"limit":1,"next":"%s/worklogs/user/acct-123?from=2024-01-01&to=2024-03-31&offset=1&limit=1"},
+				"results":[{"tempoWorklogId":1,"startDate":"2024-01-08","timeSpentSeconds":28800}]}`, server.URL)
+		case "1":
+			w.Write([]byte(`{"metadata":{"count":1,"offset":1,"limit":1},
+				"results":[{"tempoWorklogId":2,"startDate":"2024-03-04","timeSpentSeconds":28800}]}`))
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	worklogs, err := client.GetUserWorklogs("acct-123", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetUserWorklogs() error = %v", err)
+	}
+	if len(worklogs) != 2 || worklogs[0].TempoWorklogID != 1 || worklogs[1].TempoWorklogID != 2 {
+		t.Errorf("GetUserWorklogs() = %+v, want worklogs 1 and 2", worklogs)
+	}
+}
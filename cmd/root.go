@@ -59,7 +59,9 @@ func init() {
 	// Add commands
 	rootCmd.AddCommand(timecard.AddEntryCmd())
 	rootCmd.AddCommand(timecard.ConfigureCmd())
-	rootCmd.AddCommand(timecard.GetWeekCmd())
+	rootCmd.AddCommand(timecard.ShowWeekCmd())
+	rootCmd.AddCommand(timecard.BackfillCmd())
+	rootCmd.AddCommand(timecard.MissingCmd())
 
 	// Hide completion command if it was already registered
 	if compCmd, _, _ := rootCmd.Find([]string{"completion"}); compCmd != nil {
@@ -2,9 +2,12 @@ package timecard
 
 import (
 	"fmt"
-	"log"
-	"strconv"
+	"io"
+	"strings"
 	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
 )
 
 const (
@@ -13,73 +16,129 @@ const (
 	OtherTime         = "How much time did you spend on other activities i.e. meetings, etc. (in hours): "
 )
 
-func requestTimeInput() (capitalizableTime, ptoTime, otherTime int) {
-	fmt.Printf("Answer the following questions to the best of your ability and estimate how you spent your time this week.\n")
-	fmt.Printf("We will ask about 3 things: capitalizable time, PTO, and other activities.\n")
-	fmt.Printf("(For the moment, this cannot exceed a total of 40 hours)\n\n")
+const daysPerWeek = 7
+
+// maxWeeksBack is the furthest back, in weeks, add-week offers to go when asked.
+const maxWeeksBack = 52
+
+// requestTimeInput prompts for the time of every category that was not already given,
+// reminding the user how much of requiredSeconds is still missing. It returns seconds keyed by category name.
+func requestTimeInput(p *Prompter, categories []timeCategory, provided map[string]int, requiredSeconds int) (map[string]int, error) {
+	seconds := make(map[string]int, len(categories))
+	var missing []timeCategory
+	for _, category := range categories {
+		if value, ok := provided[category.Name]; ok {
+			seconds[category.Name] = value
+		} else {
+			missing = append(missing, category)
+		}
+	}
 
-	capitalizableTime = getTime(CapitalizableTime)
-	ptoTime = getTime(PtoTime)
-	otherTime = getTime(OtherTime)
+	totalSecondsThisWeek := 0
+	for _, value := range seconds {
+		totalSecondsThisWeek += value
+	}
 
-	totalHoursThisWeek := otherTime + ptoTime + capitalizableTime
+	if len(missing) > 0 {
+		fmt.Fprintf(p.out, "Answer the following questions to the best of your ability and estimate how you spent your time this week.\n")
+		fmt.Fprintf(p.out, "We will ask about %d things: %s.\n", len(missing), categoryNames(missing))
+		fmt.Fprintf(p.out, "(This week requires a total of %s)\n\n", api.FormatHours(requiredSeconds))
+	}
+	for i, category := range missing {
+		value, err := p.Time(categoryPrompt(category), configuredDayLength())
+		if err != nil {
+			return nil, err
+		}
+		seconds[category.Name] = value
+		totalSecondsThisWeek += seconds[category.Name]
+		if remaining := requiredSeconds - totalSecondsThisWeek; remaining > 0 && i < len(missing)-1 {
+			fmt.Fprintf(p.out, "You need %s more.\n", api.FormatHours(remaining))
+		}
+	}
 
-	print(fmt.Sprintf("Total hours this week: %s\n", strconv.Itoa(totalHoursThisWeek)))
-	return
+	fmt.Fprintf(p.out, "Total this week: %s\n", api.FormatHours(totalSecondsThisWeek))
+	printRequiredDifference(p.out, totalSecondsThisWeek, requiredSeconds)
+	return seconds, nil
 }
 
-func getTime(printString string) int {
-	fmt.Print(printString)
-	var timeInput string
-	if _, err := fmt.Scan(&timeInput); err != nil {
-		log.Fatal(err)
+// printRequiredDifference warns when the week's total does not match what the schedule requires.
+func printRequiredDifference(out io.Writer, totalSeconds, requiredSeconds int) {
+	switch {
+	case totalSeconds < requiredSeconds:
+		fmt.Fprintf(out, "⚠️  That is %s less than the %s this week requires.\n", api.FormatHours(requiredSeconds-totalSeconds), api.FormatHours(requiredSeconds))
+	case totalSeconds > requiredSeconds:
+		fmt.Fprintf(out, "⚠️  That is %s more than the %s this week requires.\n", api.FormatHours(totalSeconds-requiredSeconds), api.FormatHours(requiredSeconds))
 	}
-	return stringToInt(timeInput)
 }
 
-func stringToInt(input string) int {
-	var convertedValue, err = strconv.Atoi(input)
-	if err != nil {
-		log.Fatal(err)
+// categoryNames joins category names for display, e.g. "capitalizable, pto and other".
+func categoryNames(categories []timeCategory) string {
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+	if len(names) == 1 {
+		return names[0]
 	}
-	return convertedValue
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
 }
 
-func requestDayOfWeek() time.Time {
-	mondayOfThisWeek := determineWeekforTimeSheet()
-
-	fmt.Printf("Would you like to fill out time for %s (Y/N)? ", mondayOfThisWeek.Format(time.DateOnly))
-	var confirmTime string
-	if _, err := fmt.Scan(&confirmTime); err != nil {
-		log.Fatal(err)
+// missingCategoryFlags returns the flags of the categories whose time was not given on the command line.
+func missingCategoryFlags(categories []timeCategory, provided map[string]int) []string {
+	var flags []string
+	for _, category := range categories {
+		if _, ok := provided[category.Name]; !ok {
+			flags = append(flags, "--"+category.flagName())
+		}
 	}
+	return flags
+}
 
-	if confirmTime == "y" || confirmTime == "Y" {
-		return mondayOfThisWeek
+// chooseWeek returns the first day of the week to fill out: the one picked by selector when given,
+// this week when yes skips the confirmation, and otherwise the week the user is asked for.
+func chooseWeek(p *Prompter, selector string, yes bool, week calendar.Week) (time.Time, error) {
+	if selector != "" {
+		startOfWeek, err := selectWeek(selector, week.Now(), week)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("--week: %w", err)
+		}
+		fmt.Fprintf(p.out, "This will fill out the timesheet for the week of %s (%s)\n\n", startOfWeek.Format(time.DateOnly), week.ZoneName())
+		return startOfWeek, nil
 	}
-
-	fmt.Printf("\nHow many weeks back would you like to fill out (ex. 1 means last week): ")
-	var timeInput string
-	if _, err := fmt.Scan(&timeInput); err != nil {
-		log.Fatal(err)
+	if yes {
+		startOfWeek := determineWeekforTimeSheet(week)
+		fmt.Fprintf(p.out, "This will fill out the timesheet for the week of %s (%s)\n\n", startOfWeek.Format(time.DateOnly), week.ZoneName())
+		return startOfWeek, nil
+	}
+	if err := p.require("which week to fill out", "pass --week or --yes"); err != nil {
+		return time.Time{}, err
 	}
+	return requestDayOfWeek(p, week)
+}
 
-	fmt.Printf("Now we are filling out a timesheet for %s\n", mondayOfThisWeek.AddDate(0, 0, -7*stringToInt(timeInput)).Format(time.DateOnly))
+// requestDayOfWeek asks whether to fill out this week and, if not, how many weeks back to go instead.
+func requestDayOfWeek(p *Prompter, week calendar.Week) (time.Time, error) {
+	startOfThisWeek := determineWeekforTimeSheet(week)
 
-	return mondayOfThisWeek.AddDate(0, 0, -7*stringToInt(timeInput))
-}
+	thisWeek, err := p.Confirm(fmt.Sprintf("Would you like to fill out time for %s (%s)?", startOfThisWeek.Format(time.DateOnly), week.ZoneName()), true)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if thisWeek {
+		return startOfThisWeek, nil
+	}
 
-func determineWeekforTimeSheet() time.Time {
-	currentDay := time.Now()
-	dayOfTheWeek := int(currentDay.Weekday())
-	var distanceToMonday int
-	if dayOfTheWeek-1 == -1 {
-		distanceToMonday = -6
-	} else {
-		distanceToMonday = -(dayOfTheWeek - 1)
+	fmt.Fprintln(p.out)
+	weeksBack, err := p.Int("How many weeks back would you like to fill out (ex. 1 means last week):", 1, 0, maxWeeksBack)
+	if err != nil {
+		return time.Time{}, err
 	}
+	startOfWeek := startOfThisWeek.AddDate(0, 0, -daysPerWeek*weeksBack)
+	fmt.Fprintf(p.out, "Now we are filling out a timesheet for %s\n", startOfWeek.Format(time.DateOnly))
+	return startOfWeek, nil
+}
 
-	monday := currentDay.AddDate(0, 0, distanceToMonday)
-	print(fmt.Sprintf("This will fill out the timesheet for the week of %s\n\n", monday.Format(time.DateOnly)))
-	return monday
+// determineWeekforTimeSheet returns the first day of the current week.
+func determineWeekforTimeSheet(week calendar.Week) time.Time {
+	return week.StartOf(week.Now())
 }
@@ -0,0 +1,145 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const ALLOCATIONS_CONFIG = TOP_LEVEL_CONFIG + ".allocations"
+
+// parseAllocation parses an allocation such as "10012=60%,10040=40%" or "10012=6h,10040=100%".
+// Hours use the same formats as time answers and are taken before percentages share the rest.
+func parseAllocation(spec string, dayLength time.Duration) (api.Allocation, error) {
+	var allocation api.Allocation
+	seen := map[string]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		issue, amount, ok := strings.Cut(strings.TrimSpace(part), "=")
+		issue = strings.TrimSpace(issue)
+		amount = strings.TrimSpace(amount)
+		if !ok || issue == "" || amount == "" {
+			return nil, fmt.Errorf("invalid allocation %q, expected ISSUE=PERCENT%% or ISSUE=HOURS separated by commas", part)
+		}
+		if err := checkIssueID(issue); err != nil {
+			return nil, fmt.Errorf("invalid allocation: %w", err)
+		}
+		if seen[issue] {
+			return nil, fmt.Errorf("issue %s is allocated more than once", issue)
+		}
+		seen[issue] = true
+
+		share := api.IssueShare{IssueID: issue}
+		if percent, isPercent := strings.CutSuffix(amount, "%"); isPercent {
+			value, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+			if err != nil || value <= 0 || value > 100 {
+				return nil, fmt.Errorf("invalid allocation percentage %q for issue %s", amount, issue)
+			}
+			share.Percent = value
+		} else {
+			seconds, err := parseTimeInput(amount, dayLength)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allocation for issue %s: %w", issue, err)
+			}
+			share.Seconds = seconds
+		}
+		allocation = append(allocation, share)
+	}
+	return allocation, nil
+}
+
+// isInlineAllocation reports whether spec lists issues rather than naming a saved allocation.
+func isInlineAllocation(spec string) bool {
+	return strings.Contains(spec, "=")
+}
+
+// resolveAllocation parses spec, which is either an inline allocation or the name of one saved under timecard.allocations.
+func resolveAllocation(spec string) (api.Allocation, error) {
+	if !isInlineAllocation(spec) {
+		saved := viper.GetString(ALLOCATIONS_CONFIG + "." + spec)
+		if saved == "" {
+			return nil, fmt.Errorf("no allocation named %q is saved under %s", spec, ALLOCATIONS_CONFIG)
+		}
+		spec = saved
+	}
+	return parseAllocation(spec, configuredDayLength())
+}
+
+// saveAllocation stores an inline allocation under name so later runs can refer to it.
+func saveAllocation(name, spec string) error {
+	if name == "" || isInlineAllocation(name) || strings.ContainsAny(name, ".:,") {
+		return fmt.Errorf("invalid allocation name %q", name)
+	}
+	if !isInlineAllocation(spec) {
+		return fmt.Errorf("only an allocation that lists its issues can be saved, got %q", spec)
+	}
+	if _, err := parseAllocation(spec, configuredDayLength()); err != nil {
+		return err
+	}
+	viper.Set(ALLOCATIONS_CONFIG+"."+name, spec)
+	return nil
+}
+
+// allocationFlags maps each --alloc value to the category it is for. A value may start with "<category>:";
+// without one it applies to the first category.
+func allocationFlags(values []string, categories []timeCategory) (map[string]string, error) {
+	specs := map[string]string{}
+	for _, value := range values {
+		name := categories[0].Name
+		spec := value
+		if prefix, rest, ok := strings.Cut(value, ":"); ok && !isInlineAllocation(prefix) {
+			name, spec = prefix, rest
+		}
+
+		known := false
+		for _, category := range categories {
+			known = known || category.Name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("--alloc %q: %q is not a configured category", value, name)
+		}
+		if _, ok := specs[name]; ok {
+			return nil, fmt.Errorf("--alloc is given more than once for %s", name)
+		}
+		specs[name] = strings.TrimSpace(spec)
+	}
+	return specs, nil
+}
+
+// issueShares returns the IDs of the issues a category's time is logged against and the seconds for each,
+// describing any split on out. spec, when set, wins over the category's configured allocation; with neither,
+// everything goes to one issue.
+func issueShares(out io.Writer, category timeCategory, spec, defaultIssue string, seconds int, resolver *issueResolver) ([]string, []int, error) {
+	if spec == "" {
+		spec = category.Allocation
+	}
+	if spec == "" {
+		return []string{category.issueOr(defaultIssue)}, []int{seconds}, nil
+	}
+
+	allocation, err := resolveAllocation(spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("category %q: %w", category.Name, err)
+	}
+	split, err := allocation.Split(seconds, configuredIncrement())
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot allocate %s time: %w", category.Name, err)
+	}
+
+	issues := make([]string, len(allocation))
+	parts := make([]string, len(allocation))
+	for i, share := range allocation {
+		if issues[i], err = resolver.id(share.IssueID); err != nil {
+			return nil, nil, fmt.Errorf("category %q: %w", category.Name, err)
+		}
+		parts[i] = fmt.Sprintf("%s %s", resolver.key(issues[i]), api.FormatHours(split[i]))
+	}
+	if seconds > 0 {
+		fmt.Fprintf(out, "📊 Splitting %s time: %s\n", category.Name, strings.Join(parts, ", "))
+	}
+	return issues, split, nil
+}
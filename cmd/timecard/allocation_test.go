@@ -0,0 +1,111 @@
+package timecard
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+func TestParseAllocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected api.Allocation
+		wantErr  bool
+	}{
+		{
+			name:     "percentages",
+			spec:     "10012=60%, 10040=40%",
+			expected: api.Allocation{{IssueID: "10012", Percent: 60}, {IssueID: "10040", Percent: 40}},
+		},
+		{
+			name:     "hours and percentages",
+			spec:     "10012=6h,10040=1.5,10041=100%",
+			expected: api.Allocation{{IssueID: "10012", Seconds: 6 * 3600}, {IssueID: "10040", Seconds: 5400}, {IssueID: "10041", Percent: 100}},
+		},
+		{name: "missing amount", spec: "10012=", wantErr: true},
+		{name: "bad issue", spec: "proj-12=100%", wantErr: true},
+		{name: "bad percentage", spec: "10012=120%", wantErr: true},
+		{name: "duplicate issue", spec: "10012=50%,10012=50%", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAllocation(tt.spec, 8*time.Hour)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("parseAllocation() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAllocationFlags(t *testing.T) {
+	categories := defaultCategories()
+
+	specs, err := allocationFlags([]string{"10012=60%,10040=40%", "pto:vacation"}, categories)
+	if err != nil {
+		t.Fatalf("allocationFlags() error = %v", err)
+	}
+	expected := map[string]string{capitalizableCategory: "10012=60%,10040=40%", ptoCategory: "vacation"}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("allocationFlags() = %v, want %v", specs, expected)
+	}
+
+	if _, err := allocationFlags([]string{"training:10012=100%"}, categories); err == nil {
+		t.Error("expected error for an unknown category")
+	}
+	if _, err := allocationFlags([]string{"10012=100%", "capitalizable:10040=100%"}, categories); err == nil {
+		t.Error("expected error for two allocations of one category")
+	}
+}
+
+func TestSavedAllocation(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if err := saveAllocation("q4", "10012=60%,10040=40%"); err != nil {
+		t.Fatalf("saveAllocation() error = %v", err)
+	}
+	if err := saveAllocation("bad", "10012=sometimes"); err == nil {
+		t.Error("expected error saving an invalid allocation")
+	}
+	if err := saveAllocation("copy", "q4"); err == nil {
+		t.Error("expected error saving an allocation name instead of issues")
+	}
+
+	category := timeCategory{Name: capitalizableCategory, Allocation: "q4"}
+	issues, split, err := issueShares(io.Discard, category, "", "10001", 30*3600, nil)
+	if err != nil {
+		t.Fatalf("issueShares() error = %v", err)
+	}
+	if !reflect.DeepEqual(issues, []string{"10012", "10040"}) || !reflect.DeepEqual(split, []int{18 * 3600, 12 * 3600}) {
+		t.Errorf("issueShares() = %v %v, want the saved 60/40 split", issues, split)
+	}
+
+	issues, split, err = issueShares(io.Discard, category, "10050=100%", "10001", 3600, nil)
+	if err != nil || !reflect.DeepEqual(issues, []string{"10050"}) || split[0] != 3600 {
+		t.Errorf("issueShares() with a flag = %v %v %v, want the flag to win", issues, split, err)
+	}
+
+	issues, split, err = issueShares(io.Discard, timeCategory{Name: ptoCategory}, "", "10001", 3600, nil)
+	if err != nil || !reflect.DeepEqual(issues, []string{"10001"}) || split[0] != 3600 {
+		t.Errorf("issueShares() without allocation = %v %v %v, want the default issue", issues, split, err)
+	}
+
+	if _, _, err := issueShares(io.Discard, timeCategory{Name: ptoCategory, Allocation: "missing"}, "", "10001", 3600, nil); err == nil {
+		t.Error("expected error for an unknown saved allocation")
+	}
+}
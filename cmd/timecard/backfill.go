@@ -0,0 +1,336 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const PRESETS_CONFIG = TOP_LEVEL_CONFIG + ".presets"
+
+// restOfWeek is the preset value for a category that takes whatever time the week still requires.
+const restOfWeek = "rest"
+
+// timePreset is a saved answer to the time questions: each category's time, by category name.
+type timePreset map[string]string
+
+// loadPreset reads the named preset from config and checks it against the configured categories.
+func loadPreset(name string, categories []timeCategory) (timePreset, error) {
+	key := PRESETS_CONFIG + "." + name
+	if !viper.IsSet(key) {
+		return nil, fmt.Errorf("no preset named %q in %s", name, PRESETS_CONFIG)
+	}
+
+	preset := timePreset{}
+	rest := 0
+	for categoryName, value := range viper.GetStringMapString(key) {
+		// viper lower-cases keys, so categories are matched regardless of case
+		var category *timeCategory
+		for i := range categories {
+			if strings.EqualFold(categories[i].Name, categoryName) {
+				category = &categories[i]
+			}
+		}
+		if category == nil {
+			return nil, fmt.Errorf("preset %q: %q is not a configured category", name, categoryName)
+		}
+
+		value = strings.TrimSpace(value)
+		if strings.EqualFold(value, restOfWeek) {
+			rest++
+		} else if _, err := parseTimeInput(value, configuredDayLength()); err != nil {
+			return nil, fmt.Errorf("preset %q, category %q: %w", name, category.Name, err)
+		}
+		preset[category.Name] = value
+	}
+	if rest > 1 {
+		return nil, fmt.Errorf("preset %q: only one category can take the %s of the week", name, restOfWeek)
+	}
+	return preset, nil
+}
+
+// secondsFor returns each category's time for a week that requires requiredSeconds. Categories missing from
+// the preset get no time, and the category set to "rest" gets whatever the others leave of requiredSeconds.
+func (p timePreset) secondsFor(categories []timeCategory, requiredSeconds int) map[string]int {
+	seconds := make(map[string]int, len(categories))
+	rest := ""
+	total := 0
+	for _, category := range categories {
+		value, ok := p[category.Name]
+		switch {
+		case !ok:
+			seconds[category.Name] = 0
+		case strings.EqualFold(value, restOfWeek):
+			rest = category.Name
+		default:
+			// Presets are checked when loaded, so the value always parses here
+			seconds[category.Name], _ = parseTimeInput(value, configuredDayLength())
+			total += seconds[category.Name]
+		}
+	}
+	if rest != "" {
+		seconds[rest] = max(requiredSeconds-total, 0)
+	}
+	return seconds
+}
+
+// backfillWeek is one week of a backfill range and what happened to it.
+type backfillWeek struct {
+	start time.Time
+	// logged is the time already in Tempo for the week when the run started
+	logged  int
+	planned []*api.WorklogRequest
+}
+
+// missing reports whether the week had no time logged and so is filled in by the backfill.
+func (w backfillWeek) missing() bool {
+	return w.logged == 0
+}
+
+// weeksBetween returns the first day of every week from the one containing from to the one containing to.
+func weeksBetween(from, to time.Time, week calendar.Week) []time.Time {
+	var weeks []time.Time
+	last := week.StartOf(to)
+	for start := week.StartOf(from); !start.After(last); start = start.AddDate(0, 0, daysPerWeek) {
+		weeks = append(weeks, start)
+	}
+	return weeks
+}
+
+// loggedByWeek totals the worklogs that fall within each of weeks.
+func loggedByWeek(worklogs []api.WorklogResponse, weeks []time.Time, week calendar.Week) []backfillWeek {
+	index := make(map[string]int, len(weeks))
+	result := make([]backfillWeek, len(weeks))
+	for i, start := range weeks {
+		index[start.Format(time.DateOnly)] = i
+		result[i].start = start
+	}
+	for _, worklog := range worklogs {
+		day, err := week.ParseDate(worklog.StartDate)
+		if err != nil {
+			continue
+		}
+		if i, ok := index[week.StartOf(day).Format(time.DateOnly)]; ok {
+			result[i].logged += worklog.TimeSpentSeconds
+		}
+	}
+	return result
+}
+
+func BackfillCmd() *cobra.Command {
+	var from, to, preset, overrideReason string
+	var atomic, yes bool
+
+	cmd := &cobra.Command{
+		Use:     "backfill",
+		Short:   "Fill in every week without time over a range of weeks",
+		Example: "timecard backfill --from 2026-08-01 --to 2026-09-30 --preset full-time",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			categories, err := loadCategories()
+			if err != nil {
+				return err
+			}
+			week, err := loadWeek()
+			if err != nil {
+				return err
+			}
+			fromDate, err := week.ParseDate(from)
+			if err != nil {
+				return fmt.Errorf("invalid --from %q, expected YYYY-MM-DD: %w", from, err)
+			}
+			toDate := defaultRangeEnd(fromDate, week)
+			if to != "" {
+				if toDate, err = week.ParseDate(to); err != nil {
+					return fmt.Errorf("invalid --to %q, expected YYYY-MM-DD: %w", to, err)
+				}
+			}
+			if toDate.Before(fromDate) {
+				return fmt.Errorf("--to %s is before --from %s", toDate.Format(time.DateOnly), fromDate.Format(time.DateOnly))
+			}
+
+			prompter := commandPrompter(cmd)
+			out := cmd.OutOrStdout()
+			var presetTimes timePreset
+			if preset != "" {
+				if presetTimes, err = loadPreset(preset, categories); err != nil {
+					return err
+				}
+			} else if err := prompter.require("for each missing week's time", "pass --preset"); err != nil {
+				return err
+			}
+			if !yes {
+				if err := prompter.require("to confirm the submission", "pass --yes"); err != nil {
+					return err
+				}
+			}
+
+			accountId, issueId, err := fetchConfig(prompter)
+			if err != nil {
+				return err
+			}
+			resolver := configuredIssueResolver(out)
+			issueId, err = resolveIssues(resolver, issueId, categories)
+			if err != nil {
+				return err
+			}
+			holidays, err := loadHolidays()
+			if err != nil {
+				return err
+			}
+			layout, err := configuredDayLayout()
+			if err != nil {
+				return err
+			}
+			policy, err := loadWeekPolicy(categories)
+			if err != nil {
+				return err
+			}
+			planner := weekPlanner{
+				accountId:  accountId,
+				issueId:    issueId,
+				categories: categories,
+				holidays:   holidays,
+				week:       week,
+				describer: describer{
+					fallback: viper.GetString(DESCRIPTION_CONFIG),
+					holidays: holidays,
+					issues:   resolver,
+				},
+				out: out,
+			}
+			if err := planner.describer.validate(); err != nil {
+				return err
+			}
+
+			client := newTempoClient(fetchBearerToken(prompter))
+			starts := weeksBetween(fromDate, toDate, week)
+			existing, err := client.GetUserWorklogs(accountId, starts[0], starts[len(starts)-1].AddDate(0, 0, daysPerWeek-1))
+			if err != nil {
+				return fmt.Errorf("failed to fetch existing worklogs: %w", err)
+			}
+			weeks := loggedByWeek(existing, starts, week)
+
+			fmt.Fprintf(out, "Weeks from %s to %s:\n", starts[0].Format(time.DateOnly), starts[len(starts)-1].Format(time.DateOnly))
+			missing := 0
+			for _, w := range weeks {
+				if w.missing() {
+					missing++
+					fmt.Fprintf(out, "   %s  missing\n", w.start.Format(time.DateOnly))
+				} else {
+					fmt.Fprintf(out, "   %s  %s already logged\n", w.start.Format(time.DateOnly), api.FormatHours(w.logged))
+				}
+			}
+			if missing == 0 {
+				fmt.Fprintln(out, "✅ Every week in the range already has time logged.")
+				return nil
+			}
+
+			var planned []*api.WorklogRequest
+			for i := range weeks {
+				w := &weeks[i]
+				if !w.missing() {
+					continue
+				}
+				fmt.Fprintf(out, "\n🗓️  Week of %s\n", w.start.Format(time.DateOnly))
+				fullSchedule := fetchSchedule(out, client, accountId, w.start, week)
+				required := fullSchedule.withoutHolidays(holidays).requiredTotal()
+
+				var seconds map[string]int
+				if presetTimes != nil {
+					seconds = presetTimes.secondsFor(categories, required)
+					fmt.Fprintf(out, "Applying preset %q\n", preset)
+					printRequiredDifference(out, sumSeconds(seconds), required)
+				} else {
+					if seconds, err = requestTimeInput(prompter, categories, nil, required); err != nil {
+						return err
+					}
+				}
+
+				entries, err := planner.plan(w.start, fullSchedule, seconds, nil)
+				if err != nil {
+					return fmt.Errorf("week of %s: %w", w.start.Format(time.DateOnly), err)
+				}
+				overridden, err := enforceViolations(out, policy.check(tallyWeek(categories, entries, nil)), overrideReason)
+				if err != nil {
+					return fmt.Errorf("week of %s: %w", w.start.Format(time.DateOnly), err)
+				}
+				if overridden {
+					recordOverride(entries, overrideReason)
+				}
+				if w.planned, err = layout.Layout(entries, nil); err != nil {
+					return fmt.Errorf("week of %s: %w", w.start.Format(time.DateOnly), err)
+				}
+				planned = append(planned, w.planned...)
+			}
+
+			if len(planned) == 0 {
+				fmt.Fprintln(out, "Nothing to submit.")
+				return nil
+			}
+			if !yes {
+				submit, err := prompter.Confirm(fmt.Sprintf("\nSubmit %d worklog(s) for %d week(s)?", len(planned), missing), false)
+				if err != nil {
+					return err
+				}
+				if !submit {
+					return fmt.Errorf("backfill cancelled, nothing was submitted")
+				}
+			}
+
+			sub := newSubmission(client, out)
+			sub.issues = resolver
+			sub.prompter = prompter
+			if err := sub.send(planned); err != nil {
+				return sub.handleFailure(err, atomic || yes)
+			}
+
+			fmt.Fprintln(out)
+			printBackfillSummary(out, weeks)
+			fmt.Fprintln(out, "✅ Backfill submitted successfully!")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "First date of the range (YYYY-MM-DD); its whole week is included")
+	cmd.Flags().StringVar(&to, "to", "", "Last date of the range (YYYY-MM-DD), defaults to yesterday like missing; its whole week is included")
+	cmd.Flags().StringVar(&preset, "preset", "", "Name of a preset under "+PRESETS_CONFIG+" to apply to every missing week instead of asking")
+	cmd.Flags().StringVar(&overrideReason, "override-reason", "", "Submit weeks that fail validation rules, recording this reason in their worklog descriptions")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Submit without asking for confirmation, and roll back on a failed submission")
+	cmd.Flags().BoolVar(&atomic, "atomic", false, "Automatically delete worklogs created in this run if any submission fails")
+	cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// sumSeconds totals seconds keyed by category name.
+func sumSeconds(seconds map[string]int) int {
+	total := 0
+	for _, value := range seconds {
+		total += value
+	}
+	return total
+}
+
+// printBackfillSummary prints one line per week: what was already logged and what the backfill added.
+func printBackfillSummary(out io.Writer, weeks []backfillWeek) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "Week\tAlready logged\tAdded\tWorklogs\t\n")
+	weeksAdded, totalAdded := 0, 0
+	for _, week := range weeks {
+		added := plannedSeconds(week.planned)
+		if added > 0 {
+			weeksAdded++
+			totalAdded += added
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t\n", week.start.Format(time.DateOnly), formatTableHours(week.logged), formatTableHours(added), len(week.planned))
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\nAdded %s across %d week(s).\n", api.FormatHours(totalAdded), weeksAdded)
+}
@@ -0,0 +1,95 @@
+package timecard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+func TestWeeksBetween(t *testing.T) {
+	week := calendar.DefaultWeek()
+	week.Location = time.UTC
+	from := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC) // Saturday
+	to := time.Date(2026, 8, 17, 0, 0, 0, 0, time.UTC)  // Monday
+
+	var got []string
+	for _, start := range weeksBetween(from, to, week) {
+		got = append(got, start.Format(time.DateOnly))
+	}
+	expected := "2026-07-27 2026-08-03 2026-08-10 2026-08-17"
+	if strings.Join(got, " ") != expected {
+		t.Errorf("weeksBetween() = %v, want %s", got, expected)
+	}
+}
+
+func TestLoggedByWeek(t *testing.T) {
+	week := calendar.DefaultWeek()
+	week.Location = time.UTC
+	monday := time.Date(2026, 8, 3, 0, 0, 0, 0, time.UTC)
+	worklogs := []api.WorklogResponse{
+		{StartDate: "2026-08-03", TimeSpentSeconds: 8 * 3600},
+		{StartDate: "2026-08-09", TimeSpentSeconds: 3600},
+		{StartDate: "2026-08-24", TimeSpentSeconds: 3600},
+	}
+
+	weeks := loggedByWeek(worklogs, []time.Time{monday, monday.AddDate(0, 0, 7)}, week)
+	if weeks[0].logged != 9*3600 || weeks[0].missing() {
+		t.Errorf("first week = %+v, want 9 hours logged", weeks[0])
+	}
+	if weeks[1].logged != 0 || !weeks[1].missing() {
+		t.Errorf("second week = %+v, want missing", weeks[1])
+	}
+}
+
+func TestLoadPreset(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	categories := defaultCategories()
+	viper.Set(PRESETS_CONFIG, map[string]any{
+		"full-time": map[string]any{"capitalizable": "rest", "other": "4h"},
+		"two-rests": map[string]any{"capitalizable": "rest", "other": "rest"},
+		"unknown":   map[string]any{"travel": "8"},
+		"bad-time":  map[string]any{"pto": "lots"},
+	})
+
+	preset, err := loadPreset("full-time", categories)
+	if err != nil {
+		t.Fatalf("loadPreset() error = %v", err)
+	}
+	seconds := preset.secondsFor(categories, 40*3600)
+	if seconds[capitalizableCategory] != 36*3600 || seconds[otherCategory] != 4*3600 || seconds[ptoCategory] != 0 {
+		t.Errorf("secondsFor(40h) = %v, want 36h capitalizable and 4h other", seconds)
+	}
+	seconds = preset.secondsFor(categories, 2*3600)
+	if seconds[capitalizableCategory] != 0 || seconds[otherCategory] != 4*3600 {
+		t.Errorf("secondsFor(2h) = %v, want no capitalizable time", seconds)
+	}
+
+	for _, name := range []string{"two-rests", "unknown", "bad-time", "missing"} {
+		if _, err := loadPreset(name, categories); err == nil {
+			t.Errorf("loadPreset(%q) expected error", name)
+		}
+	}
+}
+
+func TestPrintBackfillSummary(t *testing.T) {
+	monday := time.Date(2026, 8, 3, 0, 0, 0, 0, time.UTC)
+	weeks := []backfillWeek{
+		{start: monday, logged: 40 * 3600},
+		{start: monday.AddDate(0, 0, 7), planned: []*api.WorklogRequest{{TimeSpentSeconds: 8 * 3600}, {TimeSpentSeconds: 32 * 3600}}},
+	}
+
+	var out bytes.Buffer
+	printBackfillSummary(&out, weeks)
+	for _, want := range []string{"2026-08-03  40", "2026-08-10  -", "Added 40 hours across 1 week(s)."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("summary missing %q:\n%s", want, out.String())
+		}
+	}
+}
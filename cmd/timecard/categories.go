@@ -0,0 +1,192 @@
+package timecard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const CATEGORIES_CONFIG = TOP_LEVEL_CONFIG + ".categories"
+
+// reservedFlags are add-week flags that a category flag alias may not shadow.
+var reservedFlags = map[string]bool{"help": true, "h": true, "force": true, "atomic": true, "distribute": true, "description": true, "notes": true, "alloc": true, "save-alloc": true, "week": true, "yes": true, "override-reason": true}
+
+// timeCategory is one kind of time the user is asked about and that becomes its own set of worklogs.
+type timeCategory struct {
+	Name      string `mapstructure:"name"`
+	Prompt    string `mapstructure:"prompt"`
+	WorkType  string `mapstructure:"workType"`
+	IssueID   string `mapstructure:"issueId"`
+	Flag      string `mapstructure:"flag"`
+	Shorthand string `mapstructure:"shorthand"`
+	// Distribute names the api.Distributor strategy used to spread this category's time.
+	Distribute string `mapstructure:"distribute"`
+	// Description is a text/template for the description of this category's worklogs.
+	Description string `mapstructure:"description"`
+	// Allocation splits this category's time across issues, inline or by the name of a saved allocation.
+	Allocation string `mapstructure:"allocation"`
+}
+
+// workType returns the _WorkType_ attribute logged for this category.
+func (c timeCategory) workType() api.WorkType {
+	return api.WorkType{Key: api.WorkTypeAttributeKey, Value: c.WorkType}
+}
+
+// issueOr returns the category's own issue, or defaultIssue when it has none.
+func (c timeCategory) issueOr(defaultIssue string) string {
+	if c.IssueID != "" {
+		return c.IssueID
+	}
+	return defaultIssue
+}
+
+// distributor builds the strategy used to spread this category's time; override, when set, wins over config.
+// With neither, time fills required, the seconds each day still needs, unless it is nil.
+func (c timeCategory) distributor(override string, required []int) (api.Distributor, error) {
+	strategy := c.Distribute
+	if override != "" {
+		strategy = override
+	}
+	if strategy == "" && required != nil {
+		return api.Scheduled{Required: required, Increment: configuredIncrement()}, nil
+	}
+	distributor, err := api.ParseDistributor(strategy, configuredIncrement(), configuredDayLength())
+	if err != nil {
+		return nil, fmt.Errorf("category %q: %w", c.Name, err)
+	}
+	return distributor, nil
+}
+
+// issueFlagName returns the add-week flag used to log this category against another issue for one run.
+func (c timeCategory) issueFlagName() string {
+	return c.Name + "-issue"
+}
+
+// flagName returns the add-week flag used to pass this category's hours.
+func (c timeCategory) flagName() string {
+	if c.Flag != "" {
+		return c.Flag
+	}
+	return c.Name + "-time"
+}
+
+// defaultCategories are the capitalizable, PTO and other categories used when none are configured.
+func defaultCategories() []timeCategory {
+	return []timeCategory{
+		{Name: capitalizableCategory, Prompt: CapitalizableTime, WorkType: workTypeFor(capitalizableCategory).Value, Flag: "capitalizable-time", Shorthand: "c"},
+		{Name: ptoCategory, Prompt: PtoTime, WorkType: workTypeFor(ptoCategory).Value, Flag: "pto-time", Shorthand: "p"},
+		{Name: otherCategory, Prompt: OtherTime, WorkType: workTypeFor(otherCategory).Value, Flag: "other-time", Shorthand: "m"},
+	}
+}
+
+// loadCategories returns the configured time categories, or the defaults when none are configured.
+func loadCategories() ([]timeCategory, error) {
+	if !viper.IsSet(CATEGORIES_CONFIG) {
+		return defaultCategories(), nil
+	}
+
+	var categories []timeCategory
+	if err := viper.UnmarshalKey(CATEGORIES_CONFIG, &categories); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", CATEGORIES_CONFIG, err)
+	}
+	if err := validateCategories(categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// validateCategories checks that categories can be prompted for and turned into unique add-week flags.
+func validateCategories(categories []timeCategory) error {
+	if len(categories) == 0 {
+		return fmt.Errorf("%s must list at least one category", CATEGORIES_CONFIG)
+	}
+
+	names := map[string]bool{}
+	flags := map[string]bool{}
+	for _, category := range categories {
+		if strings.TrimSpace(category.Name) == "" {
+			return fmt.Errorf("every category in %s needs a name", CATEGORIES_CONFIG)
+		}
+		if category.WorkType == "" {
+			return fmt.Errorf("category %q needs a workType", category.Name)
+		}
+		if names[category.Name] {
+			return fmt.Errorf("category %q is defined more than once", category.Name)
+		}
+		names[category.Name] = true
+
+		flag := category.flagName()
+		if reservedFlags[flag] || flags[flag] {
+			return fmt.Errorf("category %q cannot use flag --%s", category.Name, flag)
+		}
+		flags[flag] = true
+
+		issueFlag := category.issueFlagName()
+		if reservedFlags[issueFlag] || flags[issueFlag] {
+			return fmt.Errorf("category %q cannot use flag --%s", category.Name, issueFlag)
+		}
+		flags[issueFlag] = true
+		if category.IssueID != "" {
+			if err := checkIssueID(category.IssueID); err != nil {
+				return fmt.Errorf("category %q: %w", category.Name, err)
+			}
+		}
+
+		if _, err := api.ParseDistributor(category.Distribute, 0, 0); err != nil {
+			return fmt.Errorf("category %q: %w", category.Name, err)
+		}
+		if _, err := parseDescription(category.Name, category.Description); err != nil {
+			return err
+		}
+
+		if category.Shorthand != "" {
+			if len(category.Shorthand) != 1 || reservedFlags[category.Shorthand] || flags["-"+category.Shorthand] {
+				return fmt.Errorf("category %q cannot use shorthand -%s", category.Name, category.Shorthand)
+			}
+			flags["-"+category.Shorthand] = true
+		}
+	}
+	return nil
+}
+
+// categoryPrompt returns the question asked for a category's hours.
+func categoryPrompt(category timeCategory) string {
+	if category.Prompt != "" {
+		return category.Prompt
+	}
+	return fmt.Sprintf("How much %s time did you have this week (in hours): ", category.Name)
+}
+
+// saveCategories writes categories to config so they can be edited by hand later.
+func saveCategories(categories []timeCategory) {
+	entries := make([]map[string]string, 0, len(categories))
+	for _, category := range categories {
+		entry := map[string]string{
+			"name":     category.Name,
+			"prompt":   category.Prompt,
+			"workType": category.WorkType,
+		}
+		if category.IssueID != "" {
+			entry["issueId"] = category.IssueID
+		}
+		if category.Flag != "" {
+			entry["flag"] = category.Flag
+		}
+		if category.Shorthand != "" {
+			entry["shorthand"] = category.Shorthand
+		}
+		if category.Distribute != "" {
+			entry["distribute"] = category.Distribute
+		}
+		if category.Description != "" {
+			entry["description"] = category.Description
+		}
+		if category.Allocation != "" {
+			entry["allocation"] = category.Allocation
+		}
+		entries = append(entries, entry)
+	}
+	viper.Set(CATEGORIES_CONFIG, entries)
+}
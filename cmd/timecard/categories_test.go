@@ -0,0 +1,186 @@
+package timecard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+func TestLoadCategories_Defaults(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	categories, err := loadCategories()
+	if err != nil {
+		t.Fatalf("loadCategories() error = %v", err)
+	}
+	if len(categories) != 3 {
+		t.Fatalf("got %d categories, want 3", len(categories))
+	}
+	wantFlags := []string{"capitalizable-time", "pto-time", "other-time"}
+	wantShorthands := []string{"c", "p", "m"}
+	for i, category := range categories {
+		if category.flagName() != wantFlags[i] || category.Shorthand != wantShorthands[i] {
+			t.Errorf("category %q flag = --%s/-%s, want --%s/-%s", category.Name, category.flagName(), category.Shorthand, wantFlags[i], wantShorthands[i])
+		}
+	}
+	if categories[0].Prompt != CapitalizableTime {
+		t.Errorf("capitalizable prompt = %q, want %q", categories[0].Prompt, CapitalizableTime)
+	}
+}
+
+func TestLoadCategories_FromConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set(CATEGORIES_CONFIG, []map[string]string{
+		{"name": "dev", "workType": "14C"},
+		{"name": "training", "prompt": "Training hours: ", "workType": "15T", "issueId": "20002", "flag": "training", "shorthand": "t"},
+	})
+
+	categories, err := loadCategories()
+	if err != nil {
+		t.Fatalf("loadCategories() error = %v", err)
+	}
+	if len(categories) != 2 {
+		t.Fatalf("got %d categories, want 2", len(categories))
+	}
+	if categories[0].flagName() != "dev-time" {
+		t.Errorf("default flag = %q, want %q", categories[0].flagName(), "dev-time")
+	}
+	if categories[0].issueOr("10001") != "10001" || categories[1].issueOr("10001") != "20002" {
+		t.Errorf("issueOr() should prefer the category issue")
+	}
+	if got := categoryPrompt(categories[0]); !strings.Contains(got, "dev") {
+		t.Errorf("generated prompt %q should mention the category name", got)
+	}
+	if got := categoryPrompt(categories[1]); got != "Training hours: " {
+		t.Errorf("categoryPrompt() = %q, want configured prompt", got)
+	}
+}
+
+func TestValidateCategories(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []timeCategory
+		wantErr    string
+	}{
+		{name: "empty", categories: nil, wantErr: "at least one"},
+		{name: "missing name", categories: []timeCategory{{WorkType: "14C"}}, wantErr: "needs a name"},
+		{name: "missing work type", categories: []timeCategory{{Name: "dev"}}, wantErr: "needs a workType"},
+		{name: "duplicate name", categories: []timeCategory{{Name: "dev", WorkType: "14C"}, {Name: "dev", WorkType: "12E", Flag: "x"}}, wantErr: "more than once"},
+		{name: "duplicate flag", categories: []timeCategory{{Name: "a", WorkType: "14C", Flag: "x"}, {Name: "b", WorkType: "12E", Flag: "x"}}, wantErr: "--x"},
+		{name: "reserved flag", categories: []timeCategory{{Name: "a", WorkType: "14C", Flag: "force"}}, wantErr: "--force"},
+		{name: "help shorthand", categories: []timeCategory{{Name: "a", WorkType: "14C", Shorthand: "h"}}, wantErr: "-h"},
+		{name: "long shorthand", categories: []timeCategory{{Name: "a", WorkType: "14C", Shorthand: "ab"}}, wantErr: "-ab"},
+		{name: "unknown strategy", categories: []timeCategory{{Name: "a", WorkType: "14C", Distribute: "random"}}, wantErr: "unknown distribution"},
+		{name: "broken description", categories: []timeCategory{{Name: "a", WorkType: "14C", Description: "{{.Day"}}, wantErr: "invalid description template"},
+		{name: "issue flag clash", categories: []timeCategory{{Name: "a", WorkType: "14C", Flag: "b-issue"}, {Name: "b", WorkType: "12E"}}, wantErr: "--b-issue"},
+		{name: "bad issue", categories: []timeCategory{{Name: "a", WorkType: "14C", IssueID: "my epic"}}, wantErr: "PROJ-123"},
+		{name: "valid", categories: defaultCategories()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCategories(tt.categories)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateCategories() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCategoryNames(t *testing.T) {
+	if got := categoryNames(defaultCategories()); got != "capitalizable, pto and other" {
+		t.Errorf("categoryNames() = %q", got)
+	}
+	if got := categoryNames([]timeCategory{{Name: "dev"}}); got != "dev" {
+		t.Errorf("categoryNames() = %q, want %q", got, "dev")
+	}
+}
+
+func TestAddEntryCmd_RegistersCategoryFlags(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := "timecard:\n  categories:\n    - name: oncall\n      workType: 16O\n      flag: on-call\n      shorthand: o\n"
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	originalConfigPath := configPath
+	defer func() {
+		configPath = originalConfigPath
+	}()
+	configPath = configFile
+
+	cmd := AddEntryCmd()
+	if cmd.Flags().Lookup("on-call") != nil || viper.IsSet(CATEGORIES_CONFIG) {
+		t.Fatal("config should not be read until the command runs")
+	}
+	if err := cmd.PreRunE(cmd, []string{"-o", "4"}); err != nil {
+		t.Fatalf("PreRunE() error = %v", err)
+	}
+	flag := cmd.Flags().Lookup("on-call")
+	if flag == nil || flag.Shorthand != "o" {
+		t.Fatalf("expected --on-call/-o flag, got %v", flag)
+	}
+	if flag.Value.String() != "4" {
+		t.Errorf("--on-call = %q, want %q", flag.Value.String(), "4")
+	}
+	if cmd.Flags().Lookup("capitalizable-time") != nil {
+		t.Error("default category flags should not be registered when categories are configured")
+	}
+}
+
+func TestCategoryDistributor(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	category := timeCategory{Name: "pto", WorkType: "20E", Distribute: "fill-days"}
+
+	distributor, err := category.distributor("", nil)
+	if err != nil {
+		t.Fatalf("distributor() error = %v", err)
+	}
+	if _, ok := distributor.(api.FillDays); !ok {
+		t.Errorf("distributor() = %T, want api.FillDays from config", distributor)
+	}
+
+	distributor, err = category.distributor("even", nil)
+	if err != nil {
+		t.Fatalf("distributor(even) error = %v", err)
+	}
+	if _, ok := distributor.(api.Even); !ok {
+		t.Errorf("distributor(even) = %T, want the --distribute override", distributor)
+	}
+
+	if _, err := category.distributor("sideways", nil); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+
+	category.Distribute = ""
+	if distributor, _ = category.distributor("", []int{10 * 3600, 0}); distributor == nil {
+		t.Fatal("distributor() returned nil")
+	}
+	if _, ok := distributor.(api.Scheduled); !ok {
+		t.Errorf("distributor() = %T, want api.Scheduled following the required hours", distributor)
+	}
+	if distributor, _ = category.distributor("", nil); distributor == nil {
+		t.Fatal("distributor() returned nil")
+	}
+	if _, ok := distributor.(api.FrontLoaded); !ok {
+		t.Errorf("distributor() = %T, want api.FrontLoaded without a schedule", distributor)
+	}
+}
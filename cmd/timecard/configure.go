@@ -1,13 +1,13 @@
 package timecard
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/danlafeir/devctl-timecard/api"
 	"github.com/danlafeir/devctl/pkg/secrets"
@@ -19,74 +19,168 @@ const SECRETS_NAMESPACE = "timecard"
 const API_TOKEN_NAME = "jira-api-token"
 const ACCOUNT_ID_CONFIG = TOP_LEVEL_CONFIG + ".tempo.accountId"
 const ISSUE_ID_CONFIG = TOP_LEVEL_CONFIG + ".tempo.issueId"
+const BASE_URL_CONFIG = TOP_LEVEL_CONFIG + ".tempo.baseUrl"
+const MAX_ATTEMPTS_CONFIG = TOP_LEVEL_CONFIG + ".tempo.maxAttempts"
+
+const tempoRequestTimeout = 30 * time.Second
+
+// recentIssueDays is how far back configure looks for issues to offer as the default.
+const recentIssueDays = 30
+
+// maxRecentIssues is how many recent issues the picker lists.
+const maxRecentIssues = 10
 
 var configPath string
 
-func configureApiToken(apiToken string) string {
-	token := strings.TrimSpace(apiToken)
-	if token == "" {
-		fmt.Print("Enter your Tempo API token: ")
-		scanner := bufio.NewScanner(os.Stdin)
-		scanner.Scan()
-		token = strings.TrimSpace(scanner.Text())
+// readSecret and writeSecret reach the keychain; tests replace them.
+var readSecret, writeSecret = secrets.Read, secrets.Write
+
+// notEmpty rejects blank answers to questions that need one.
+func notEmpty(what string) func(string) error {
+	return func(answer string) error {
+		if answer == "" {
+			return fmt.Errorf("%s cannot be empty", what)
+		}
+		return nil
 	}
+}
 
+func configureApiToken(p *Prompter, apiToken string) string {
+	token := strings.TrimSpace(apiToken)
+	if token == "" && !p.interactive {
+		if saved, err := readSecret(SECRETS_NAMESPACE, API_TOKEN_NAME); err == nil && saved != "" {
+			return saved
+		}
+	}
 	if token == "" {
-		fmt.Println("Token cannot be empty. Re-run the configure command")
-		os.Exit(1)
+		p.exitOnError(p.require("for the Tempo API token", "pass --token"))
+		var err error
+		token, err = p.Ask("Enter your Tempo API token:", "", notEmpty("Token"))
+		p.exitOnError(err)
 	}
 
-	if err := secrets.Write(SECRETS_NAMESPACE, API_TOKEN_NAME, token); err != nil {
-		fmt.Println("Failed to write token to keychain:", err)
-		os.Exit(1)
+	if err := writeSecret(SECRETS_NAMESPACE, API_TOKEN_NAME, token); err != nil {
+		p.exitOnError(fmt.Errorf("Failed to write token to keychain: %w", err))
 	}
-	fmt.Println("Tempo API token saved securely to keychain.")
+	fmt.Fprintln(p.out, "Tempo API token saved securely to keychain.")
 	return token
 }
 
-func configureAccountId(accountId string) {
-	if accountId == "" {
-		fmt.Print("Add Tempo Account Id here: ")
-		scanner := bufio.NewScanner(os.Stdin)
-		scanner.Scan()
-		accountId = strings.TrimSpace(scanner.Text())
-		fmt.Print("\n")
+func configureAccountId(p *Prompter, accountId string) {
+	if accountId == "" && !p.interactive && viper.GetString(ACCOUNT_ID_CONFIG) != "" {
+		return
 	}
 	if accountId == "" {
-		fmt.Println("Account ID cannot be empty.")
-		os.Exit(1)
+		p.exitOnError(p.require("for the Tempo account ID", "pass --account-id"))
+		var err error
+		accountId, err = p.Ask("Add Tempo Account Id here:", "", notEmpty("Account ID"))
+		p.exitOnError(err)
+		fmt.Fprint(p.out, "\n")
 	}
 	viper.Set(ACCOUNT_ID_CONFIG, accountId)
 }
 
-func configureIssueId(accountId string) {
+// configureIssueId sets the default issue. A non-empty issue is used as given; otherwise the user picks
+// from the issues they logged the most time against recently, or types one in. Without a terminal the
+// current default issue is kept.
+func configureIssueId(p *Prompter, accountId string, issue string) {
+	if issue != "" {
+		p.exitOnError(checkIssueID(issue))
+		viper.Set(ISSUE_ID_CONFIG, issue)
+		return
+	}
+	if !p.interactive && viper.GetString(ISSUE_ID_CONFIG) != "" {
+		return
+	}
+	p.exitOnError(p.require("for the default issue", "pass --issue"))
 	if accountId == "" {
-		fmt.Println("Account ID is required to fetch recent issue ID. Please configure account ID first.")
-		os.Exit(1)
+		p.exitOnError(fmt.Errorf("Account ID is required to fetch recent issue ID. Please configure account ID first."))
 	}
 
-	bearerToken := fetchBearerToken()
-	fmt.Print("Fetching recent issue ID from Tempo API...\n")
-	recentIssueId, err := api.GetRecentIssueId(accountId, bearerToken)
+	week, err := loadWeek()
+	p.exitOnError(err)
+	client := newTempoClient(fetchBearerToken(p))
+	fmt.Fprint(p.out, "Fetching recent issues from Tempo API...\n")
+	recent, err := client.GetRecentIssues(accountId, week.Now().AddDate(0, 0, -recentIssueDays))
 	if err != nil {
-		fmt.Printf("Failed to fetch recent issue ID: %v\n", err)
-		fmt.Print("Enter your default Issue ID manually: ")
-		scanner := bufio.NewScanner(os.Stdin)
-		scanner.Scan()
-		id := strings.TrimSpace(scanner.Text())
-		if id == "" {
-			fmt.Println("Issue ID cannot be empty.")
-			os.Exit(1)
+		fmt.Fprintf(p.out, "Failed to fetch recent issues: %v\n", err)
+	}
+	if len(recent) > maxRecentIssues {
+		recent = recent[:maxRecentIssues]
+	}
+
+	resolver := configuredIssueResolver(p.out)
+	current := viper.GetString(ISSUE_ID_CONFIG)
+	if len(recent) > 0 {
+		fmt.Fprintln(p.out, "Issues you logged time against recently:")
+		for i, issue := range recent {
+			fmt.Fprintf(p.out, "  %d) %s - %s (%d worklogs, last on %s)\n", i+1, resolver.key(strconv.Itoa(issue.IssueID)), api.FormatHours(issue.Seconds), issue.Worklogs, issue.LastLogged)
+		}
+		if current == "" {
+			current = "1"
 		}
-		viper.Set(ISSUE_ID_CONFIG, id)
-		return
 	}
 
-	id := strconv.Itoa(recentIssueId)
-	fmt.Printf("Found recent issue ID: %s\n", id)
+	question := "Enter your default issue key or ID:"
+	if len(recent) > 0 {
+		question = "Which issue should time be logged against by default? Pick a number or enter a key or ID:"
+	}
+	answer, err := p.Ask(question, current, func(answer string) error {
+		if _, ok := resolveIssueChoice(answer, "", recent); !ok {
+			return fmt.Errorf("enter a number from the list, an issue key such as PROJ-123 or a numeric issue ID")
+		}
+		return nil
+	})
+	p.exitOnError(err)
+	id, _ := resolveIssueChoice(answer, "", recent)
+	fmt.Fprintf(p.out, "Default issue: %s\n", resolver.key(id))
 	viper.Set(ISSUE_ID_CONFIG, id)
 }
 
+// resolveIssueChoice turns a picker answer into an issue: a number picks from recent, anything else must be
+// an issue key or ID. A blank answer keeps current, which may itself be a number from the list.
+func resolveIssueChoice(answer, current string, recent []api.RecentIssue) (string, bool) {
+	if answer == "" {
+		answer = current
+	}
+	if answer == "" {
+		return "", false
+	}
+	if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(recent) {
+		return strconv.Itoa(recent[index-1].IssueID), true
+	}
+	if checkIssueID(answer) != nil {
+		return "", false
+	}
+	return answer, true
+}
+
+// configureCategoryIssues asks which issue each category's time is logged against, by key or ID. A blank
+// answer keeps the current issue, and a category whose issue is the default one keeps following the default.
+// Without a terminal nothing is asked and every category keeps its issue.
+func configureCategoryIssues(p *Prompter, categories []timeCategory, defaultIssue string, resolver *issueResolver) {
+	if !p.interactive {
+		return
+	}
+	defaultID, _ := resolver.id(defaultIssue)
+	for i := range categories {
+		current := categories[i].issueOr(defaultIssue)
+		if id, err := resolver.id(current); err == nil {
+			current = resolver.key(id)
+		}
+		answer, err := p.Ask(fmt.Sprintf("Which issue is %s time logged against?", categories[i].Name), current, func(answer string) error {
+			_, err := resolver.id(answer)
+			return err
+		})
+		p.exitOnError(err)
+		if id, _ := resolver.id(answer); answer == defaultIssue || id == defaultID {
+			answer = ""
+		}
+		categories[i].IssueID = answer
+	}
+	saveCategories(categories)
+}
+
 func getConfigPath() string {
 	if configPath != "" {
 		return configPath
@@ -144,33 +238,54 @@ func initConfig() {
 	viper.AddConfigPath(configDir)
 }
 
-func fetchConfig() (accountId string, issueId string) {
+// readConfigQuietly loads the config file if one exists, without creating it or failing.
+// Commands use it to register flags that depend on config.
+func readConfigQuietly() {
+	viper.SetConfigFile(getConfigPath())
+	viper.ReadInConfig()
+}
+
+// fetchConfig reads config and returns the account ID and default issue, asking for any that are missing.
+// Without a terminal a missing one is an error that points at configure instead.
+func fetchConfig(p *Prompter) (accountId string, issueId string, err error) {
 	initConfig()
-	err := viper.ReadInConfig()
-	if err != nil {
+	if err := viper.ReadInConfig(); err != nil {
 		panic(fmt.Errorf("fatal error config file: %w", err))
 	}
+	warnInvalidDurations(p.errOut)
 
-	if !viper.IsSet(ACCOUNT_ID_CONFIG) {
-		configureAccountId("")
-	} else {
-		accountId = viper.GetString(ACCOUNT_ID_CONFIG)
+	if viper.GetString(ACCOUNT_ID_CONFIG) == "" {
+		if err := p.require("for the Tempo account ID", "account ID not configured; run `timecard configure --account-id …`"); err != nil {
+			return "", "", err
+		}
+		configureAccountId(p, "")
 	}
+	accountId = viper.GetString(ACCOUNT_ID_CONFIG)
 
-	if !viper.IsSet(ISSUE_ID_CONFIG) {
-		configureIssueId(accountId)
-	} else {
-		issueId = viper.GetString(ISSUE_ID_CONFIG)
+	if viper.GetString(ISSUE_ID_CONFIG) == "" {
+		if err := p.require("for the default issue", "issue not configured; run `timecard configure --issue …`"); err != nil {
+			return "", "", err
+		}
+		configureIssueId(p, accountId, "")
 	}
+	return accountId, viper.GetString(ISSUE_ID_CONFIG), nil
+}
 
-	return
+// newTempoClient builds a Tempo API client, honoring optional base URL and retry overrides in config.
+func newTempoClient(bearerToken string) *api.Client {
+	return api.NewClient(bearerToken,
+		api.WithBaseURL(viper.GetString(BASE_URL_CONFIG)),
+		api.WithTimeout(tempoRequestTimeout),
+		api.WithMaxAttempts(viper.GetInt(MAX_ATTEMPTS_CONFIG)),
+	)
 }
 
-func fetchBearerToken() string {
-	bearerToken, err := secrets.Read(SECRETS_NAMESPACE, API_TOKEN_NAME)
+func fetchBearerToken(p *Prompter) string {
+	bearerToken, err := readSecret(SECRETS_NAMESPACE, API_TOKEN_NAME)
 
 	if bearerToken == "" || err != nil {
-		return configureApiToken("")
+		p.exitOnError(p.require("for the Tempo API token", "token not configured; run `timecard configure --token …`"))
+		return configureApiToken(p, "")
 	}
 	return bearerToken
 }
@@ -1,10 +1,16 @@
 package timecard
 
 import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/danlafeir/devctl-timecard/api"
 	"github.com/spf13/viper"
 )
 
@@ -101,7 +107,7 @@ func TestConfigureAccountId(t *testing.T) {
 			// Since configureAccountId calls os.Exit on empty input and we can't easily
 			// mock stdin in standard Go tests, we'll only test the happy path
 			if tt.inputId != "" {
-				configureAccountId(tt.inputId)
+				configureAccountId(NewPrompter(strings.NewReader(""), io.Discard), tt.inputId)
 				
 				result := viper.GetString(tt.expectedKey)
 				if result != tt.expectedVal {
@@ -112,9 +118,154 @@ func TestConfigureAccountId(t *testing.T) {
 	}
 }
 
-// Note: TestConfigureIssueId is removed because configureIssueId now always
-// fetches from the API and cannot accept a direct input. Testing would require
-// mocking the API call, which is beyond the scope of unit tests.
+func TestConfigureIssueId(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	// An issue given on the command line skips the recent issue picker
+	configureIssueId(NewPrompter(strings.NewReader(""), io.Discard), "", "PROJ-7")
+	if got := viper.GetString(ISSUE_ID_CONFIG); got != "PROJ-7" {
+		t.Errorf("expected PROJ-7, got %s", got)
+	}
+}
+
+func TestConfigureCmd_WithoutTerminal(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	originalConfigPath := configPath
+	defer func() {
+		configPath = originalConfigPath
+	}()
+	configPath = filepath.Join(t.TempDir(), "config.yaml")
+
+	saved := map[string]string{}
+	originalRead, originalWrite := readSecret, writeSecret
+	defer func() {
+		readSecret, writeSecret = originalRead, originalWrite
+	}()
+	readSecret = func(namespace, name string) (string, error) { return saved[name], nil }
+	writeSecret = func(namespace, name, value string) error {
+		saved[name] = value
+		return nil
+	}
+
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+	defer stdin.Close()
+
+	var out bytes.Buffer
+	cmd := ConfigureCmd()
+	cmd.SetIn(stdin)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--token", "abc", "--account-id", "acc", "--issue", "10001"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out.String())
+	}
+
+	if strings.Contains(out.String(), "?") {
+		t.Errorf("expected no questions without a terminal, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Configuration saved successfully.") {
+		t.Errorf("expected the configuration to be saved, got:\n%s", out.String())
+	}
+	if saved[API_TOKEN_NAME] != "abc" {
+		t.Errorf("token = %q, want %q", saved[API_TOKEN_NAME], "abc")
+	}
+	if got := viper.GetString(ACCOUNT_ID_CONFIG); got != "acc" {
+		t.Errorf("account ID = %q, want %q", got, "acc")
+	}
+	if got := viper.GetString(ISSUE_ID_CONFIG); got != "10001" {
+		t.Errorf("issue ID = %q, want %q", got, "10001")
+	}
+
+	// Running again without flags keeps what is configured
+	out.Reset()
+	cmd = ConfigureCmd()
+	cmd.SetIn(stdin)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out.String())
+	}
+	if got := viper.GetString(ACCOUNT_ID_CONFIG); got != "acc" {
+		t.Errorf("account ID = %q, want it kept as %q", got, "acc")
+	}
+	if got := viper.GetString(ISSUE_ID_CONFIG); got != "10001" {
+		t.Errorf("issue ID = %q, want it kept as %q", got, "10001")
+	}
+}
+
+func TestConfigureCmd_IssueFlagSkipsCategoryIssues(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	originalConfigPath := configPath
+	defer func() {
+		configPath = originalConfigPath
+	}()
+	configPath = filepath.Join(t.TempDir(), "config.yaml")
+
+	originalRead, originalWrite := readSecret, writeSecret
+	defer func() {
+		readSecret, writeSecret = originalRead, originalWrite
+	}()
+	readSecret = func(namespace, name string) (string, error) { return "saved", nil }
+	writeSecret = func(namespace, name, value string) error { return nil }
+
+	// Work types cannot be fetched, so the only questions left would be about category issues
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	var out bytes.Buffer
+	cmd := ConfigureCmd()
+	cmd.SetIn(strings.NewReader(""))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--token", "abc", "--account-id", "acc", "--issue", "10001", "--base-url", server.URL,
+		"--jira-url", "https://example.atlassian.net", "--jira-email", "me@example.com", "--jira-token", "jira"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v\n%s", err, out.String())
+	}
+	if strings.Contains(out.String(), "Which issue is") {
+		t.Errorf("expected no category issue questions with --issue, got:\n%s", out.String())
+	}
+	if got := viper.GetString(ISSUE_ID_CONFIG); got != "10001" {
+		t.Errorf("issue ID = %q, want %q", got, "10001")
+	}
+}
+
+func TestResolveIssueChoice(t *testing.T) {
+	recent := []api.RecentIssue{{IssueID: 10012}, {IssueID: 10040}}
+
+	tests := []struct {
+		name     string
+		answer   string
+		current  string
+		recent   []api.RecentIssue
+		expected string
+		ok       bool
+	}{
+		{name: "pick by number", answer: "2", recent: recent, expected: "10040", ok: true},
+		{name: "blank picks the default number", current: "1", recent: recent, expected: "10012", ok: true},
+		{name: "blank keeps the current issue", current: "PROJ-3", recent: recent, expected: "PROJ-3", ok: true},
+		{name: "issue key", answer: "PROJ-12", recent: recent, expected: "PROJ-12", ok: true},
+		{name: "numeric issue ID", answer: "10099", recent: recent, expected: "10099", ok: true},
+		{name: "number without a list", answer: "2", expected: "2", ok: true},
+		{name: "nothing to keep", ok: false},
+		{name: "not an issue", answer: "the usual", recent: recent, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveIssueChoice(tt.answer, tt.current, tt.recent)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("resolveIssueChoice() = %q, %v; want %q, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
 
 func TestFetchConfig(t *testing.T) {
 	tests := []struct {
@@ -164,7 +315,10 @@ func TestFetchConfig(t *testing.T) {
 			configPath = configFile
 
 			// Only test the happy path where config exists
-			accountId, issueId := fetchConfig()
+			accountId, issueId, err := fetchConfig(NewPrompter(strings.NewReader(""), io.Discard))
+			if err != nil {
+				t.Fatalf("fetchConfig() error = %v", err)
+			}
 
 			if accountId != tt.expectedAccountId {
 				t.Errorf("expected accountId %s, got %s", tt.expectedAccountId, accountId)
@@ -176,6 +330,37 @@ func TestFetchConfig(t *testing.T) {
 	}
 }
 
+func TestFetchConfig_WithoutTerminal(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	originalConfigPath := configPath
+	defer func() {
+		configPath = originalConfigPath
+	}()
+	configPath = filepath.Join(t.TempDir(), "config.yaml")
+
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+	defer stdin.Close()
+
+	var out bytes.Buffer
+	p := NewPrompter(stdin, &out)
+	if _, _, err := fetchConfig(p); err == nil || !strings.Contains(err.Error(), "timecard configure --account-id") {
+		t.Errorf("fetchConfig() error = %v, want it to point at configure --account-id", err)
+	}
+
+	viper.Set(ACCOUNT_ID_CONFIG, "acc")
+	if _, _, err := fetchConfig(p); err == nil || !strings.Contains(err.Error(), "timecard configure --issue") {
+		t.Errorf("fetchConfig() error = %v, want it to point at configure --issue", err)
+	}
+	if out.Len() > 0 {
+		t.Errorf("expected nothing to be asked, got %q", out.String())
+	}
+}
+
 // Note: Tests for configureApiToken and fetchBearerToken are skipped because they:
 // 1. Interact with external secrets storage
 // 2. Call os.Exit() on invalid input
@@ -0,0 +1,138 @@
+package timecard
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+)
+
+const DESCRIPTION_CONFIG = TOP_LEVEL_CONFIG + ".description"
+const PROMPT_NOTES_CONFIG = TOP_LEVEL_CONFIG + ".promptNotes"
+
+// defaultDescription is used when neither the category nor the config sets a description template.
+const defaultDescription = "{{.Category}} time for the week of {{.Week}}{{with .Holiday}} ({{.}}){{end}}{{with .Notes}}: {{.}}{{end}}"
+
+// descriptionData are the values a description template can use.
+type descriptionData struct {
+	// Week is the first day of the week, e.g. 2026-10-12
+	Week string
+	// Category is the name of the time category
+	Category string
+	// Day is the worklog's date and Weekday its day name, e.g. Monday
+	Day     string
+	Weekday string
+	// Issue is the issue ID and IssueKey its key; the key is the ID when it cannot be looked up
+	Issue    string
+	IssueKey string
+	// Holiday is the name of the holiday on Day, if any
+	Holiday string
+	// Notes is what the user entered for Day, if anything
+	Notes string
+}
+
+// parseDescription compiles a description template so mistakes are reported before anything is submitted.
+func parseDescription(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description template for %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// describer fills in the descriptions of a week's planned worklogs.
+type describer struct {
+	startOfWeek time.Time
+	// override replaces every category's template when set
+	override string
+	// fallback is the template for categories without their own
+	fallback string
+	notes    map[string]string
+	holidays calendar.Holidays
+	issues   *issueResolver
+}
+
+// validate checks the override and fallback templates before any time is planned.
+func (d describer) validate() error {
+	if _, err := parseDescription("--description", d.override); err != nil {
+		return err
+	}
+	if _, err := parseDescription(DESCRIPTION_CONFIG, d.fallback); err != nil {
+		return err
+	}
+	return nil
+}
+
+// templateFor returns the description template used for category.
+func (d describer) templateFor(category timeCategory) (*template.Template, error) {
+	text := d.override
+	if text == "" {
+		text = category.Description
+	}
+	if text == "" {
+		text = d.fallback
+	}
+	if text == "" {
+		text = defaultDescription
+	}
+	return parseDescription(category.Name, text)
+}
+
+// describe sets the description of each of a category's planned worklogs.
+func (d describer) describe(entries []*api.WorklogRequest, category timeCategory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	tmpl, err := d.templateFor(category)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		day, err := time.ParseInLocation(time.DateOnly, entry.StartDate, d.startOfWeek.Location())
+		if err != nil {
+			return fmt.Errorf("invalid worklog date %q: %w", entry.StartDate, err)
+		}
+		data := descriptionData{
+			Week:     d.startOfWeek.Format(time.DateOnly),
+			Category: category.Name,
+			Day:      entry.StartDate,
+			Weekday:  day.Weekday().String(),
+			Issue:    entry.IssueID,
+			IssueKey: d.issues.key(entry.IssueID),
+			Holiday:  d.holidays.Name(day),
+			Notes:    d.notes[entry.StartDate],
+		}
+
+		var description bytes.Buffer
+		if err := tmpl.Execute(&description, data); err != nil {
+			return fmt.Errorf("failed to render description for %s: %w", category.Name, err)
+		}
+		entry.Description = strings.TrimSpace(description.String())
+	}
+	return nil
+}
+
+// requestNotes asks for an optional note on each day; days left blank get no note.
+func requestNotes(p *Prompter, days []time.Time) (map[string]string, error) {
+	notes := map[string]string{}
+	if len(days) == 0 {
+		return notes, nil
+	}
+
+	fmt.Fprintln(p.out, "\nAdd a note for each day, or leave it blank to skip.")
+	for _, day := range days {
+		note, err := p.Ask(fmt.Sprintf("Notes for %s %s:", day.Format("Mon"), day.Format(time.DateOnly)), "", nil)
+		if err != nil {
+			return nil, err
+		}
+		if note != "" {
+			notes[day.Format(time.DateOnly)] = note
+		}
+	}
+	return notes, nil
+}
@@ -0,0 +1,96 @@
+package timecard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+)
+
+func TestDescribe(t *testing.T) {
+	monday := time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC)
+	category := timeCategory{Name: "capitalizable", WorkType: "14C"}
+	holidays := map[string]string{"2026-12-25": "Christmas Day"}
+
+	tests := []struct {
+		name      string
+		describer describer
+		category  timeCategory
+		date      time.Time
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:     "default",
+			category: category,
+			date:     monday,
+			expected: "capitalizable time for the week of 2026-12-21",
+		},
+		{
+			name:      "default with holiday and notes",
+			describer: describer{holidays: holidays, notes: map[string]string{"2026-12-25": "on call"}},
+			category:  category,
+			date:      monday.AddDate(0, 0, 4),
+			expected:  "capitalizable time for the week of 2026-12-21 (Christmas Day): on call",
+		},
+		{
+			name:     "category template",
+			category: timeCategory{Name: "training", Description: "{{.Category}} on {{.Weekday}} {{.Day}} ({{.IssueKey}})"},
+			date:     monday.AddDate(0, 0, 1),
+			expected: "training on Tuesday 2026-12-22 (10001)",
+		},
+		{
+			name:      "config fallback",
+			describer: describer{fallback: "Week {{.Week}}"},
+			category:  category,
+			date:      monday,
+			expected:  "Week 2026-12-21",
+		},
+		{
+			name:      "override wins",
+			describer: describer{override: "Sprint work", fallback: "Week {{.Week}}"},
+			category:  timeCategory{Name: "training", Description: "{{.Category}}"},
+			date:      monday,
+			expected:  "Sprint work",
+		},
+		{
+			name:     "unknown field",
+			category: timeCategory{Name: "training", Description: "{{.Ticket}}"},
+			date:     monday,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.describer.startOfWeek = monday
+			entries := []*api.WorklogRequest{{StartDate: tt.date.Format(time.DateOnly), IssueID: "10001"}}
+
+			err := tt.describer.describe(entries, tt.category)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entries[0].Description != tt.expected {
+				t.Errorf("description = %q, want %q", entries[0].Description, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDescriberValidate(t *testing.T) {
+	if err := (describer{override: "{{.Category}"}).validate(); err == nil {
+		t.Error("expected error for a broken --description template")
+	}
+	if err := (describer{fallback: "{{if .Notes}}"}).validate(); err == nil {
+		t.Error("expected error for a broken config template")
+	}
+	if err := (describer{override: "{{.Category}}", fallback: "plain text"}).validate(); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+}
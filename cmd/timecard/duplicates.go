@@ -0,0 +1,100 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+)
+
+// duplicateEntry is a planned worklog that matches one already in Tempo.
+type duplicateEntry struct {
+	planned  *api.WorklogRequest
+	existing api.WorklogResponse
+}
+
+// duplicateReport summarizes how a planned week overlaps with what is already logged.
+type duplicateReport struct {
+	duplicates    []duplicateEntry
+	existingHours float64
+	plannedHours  float64
+	expectedHours float64
+}
+
+func (r duplicateReport) overExpected() bool {
+	return r.existingHours+r.plannedHours > r.expectedHours
+}
+
+func (r duplicateReport) hasProblems() bool {
+	return len(r.duplicates) > 0 || r.overExpected()
+}
+
+// findDuplicates compares planned worklogs with existing ones by date, work type and issue.
+// expectedSeconds is the most time the week should hold before submission is questioned.
+func findDuplicates(planned []*api.WorklogRequest, existing []api.WorklogResponse, expectedSeconds int) duplicateReport {
+	report := duplicateReport{expectedHours: float64(expectedSeconds) / 3600}
+
+	for _, worklog := range existing {
+		report.existingHours += float64(worklog.TimeSpentSeconds) / 3600
+	}
+
+	for _, req := range planned {
+		report.plannedHours += float64(req.TimeSpentSeconds) / 3600
+		for _, worklog := range existing {
+			if isSameEntry(req, worklog) {
+				report.duplicates = append(report.duplicates, duplicateEntry{planned: req, existing: worklog})
+				break
+			}
+		}
+	}
+	return report
+}
+
+func isSameEntry(req *api.WorklogRequest, worklog api.WorklogResponse) bool {
+	if req.StartDate != worklog.StartDate || req.IssueID != strconv.Itoa(worklog.Issue.ID) {
+		return false
+	}
+	for _, attr := range req.Attributes {
+		if worklog.AttributeValue(attr.Key) != attr.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// printDuplicateReport explains why the planned week looks like a repeat submission.
+func printDuplicateReport(out io.Writer, report duplicateReport, resolver *issueResolver) {
+	if len(report.duplicates) > 0 {
+		fmt.Fprintf(out, "⚠️  %d planned worklog(s) already exist in Tempo:\n", len(report.duplicates))
+		for _, dup := range report.duplicates {
+			fmt.Fprintf(out, "   %s  %-4s  issue %s  (already logged %s, worklog %d)\n",
+				dup.planned.StartDate, dup.planned.Attributes[0].Value, resolver.key(dup.planned.IssueID),
+				api.FormatHours(dup.existing.TimeSpentSeconds), dup.existing.TempoWorklogID)
+		}
+	}
+	if report.overExpected() {
+		fmt.Fprintf(out, "⚠️  This week already has %g hours logged; adding %g would make %g, over the expected %g hours.\n",
+			report.existingHours, report.plannedHours, report.existingHours+report.plannedHours, report.expectedHours)
+	}
+}
+
+// checkForDuplicates compares the planned worklogs with what is already logged for the week and asks before
+// submitting anything that would double up or go over expectedSeconds. It returns an error when the user declines.
+func checkForDuplicates(p *Prompter, existing []api.WorklogResponse, startOfWeek time.Time, planned []*api.WorklogRequest, expectedSeconds int, resolver *issueResolver) error {
+	report := findDuplicates(planned, existing, expectedSeconds)
+	if !report.hasProblems() {
+		return nil
+	}
+
+	printDuplicateReport(p.out, report, resolver)
+	cancelled := fmt.Errorf("submission cancelled: the week of %s already has time logged (use --force to override)", startOfWeek.Format(time.DateOnly))
+	if !p.interactive {
+		return cancelled
+	}
+	if submit, err := p.Confirm("Submit anyway?", false); err != nil || !submit {
+		return cancelled
+	}
+	return nil
+}
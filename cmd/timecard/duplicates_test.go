@@ -0,0 +1,106 @@
+package timecard
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+)
+
+func existingWorklog(id int, date string, hours int, issueID int, workType string) api.WorklogResponse {
+	return api.WorklogResponse{
+		TempoWorklogID:   id,
+		Issue:            api.Issue{ID: issueID},
+		StartDate:        date,
+		TimeSpentSeconds: hours * 3600,
+		Attributes: api.WorklogAttributes{Values: []api.WorkType{
+			{Key: api.WorkTypeAttributeKey, Value: workType},
+		}},
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	planned := mustPlan(t, api.WorklogPlan{WorkType: api.CapitalizableWorkType, Seconds: 40 * 3600, StartDay: monday, AccountID: "acct", IssueID: "10001"})
+
+	tests := []struct {
+		name           string
+		existing       []api.WorklogResponse
+		wantDuplicates int
+		wantOver       bool
+	}{
+		{
+			name:           "empty week",
+			existing:       nil,
+			wantDuplicates: 0,
+			wantOver:       false,
+		},
+		{
+			name: "same week submitted twice",
+			existing: []api.WorklogResponse{
+				existingWorklog(1, "2024-01-08", 8, 10001, "14C"),
+				existingWorklog(2, "2024-01-09", 8, 10001, "14C"),
+			},
+			wantDuplicates: 2,
+			wantOver:       true,
+		},
+		{
+			name: "different issue is not a duplicate but still over hours",
+			existing: []api.WorklogResponse{
+				existingWorklog(1, "2024-01-08", 8, 20002, "14C"),
+			},
+			wantDuplicates: 0,
+			wantOver:       true,
+		},
+		{
+			name: "different work type is not a duplicate",
+			existing: []api.WorklogResponse{
+				existingWorklog(1, "2024-01-08", 0, 10001, "20E"),
+			},
+			wantDuplicates: 0,
+			wantOver:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := findDuplicates(planned, tt.existing, 40*3600)
+			if len(report.duplicates) != tt.wantDuplicates {
+				t.Errorf("duplicates = %d, want %d", len(report.duplicates), tt.wantDuplicates)
+			}
+			if report.overExpected() != tt.wantOver {
+				t.Errorf("overExpected() = %v, want %v", report.overExpected(), tt.wantOver)
+			}
+			if report.hasProblems() != (tt.wantDuplicates > 0 || tt.wantOver) {
+				t.Errorf("hasProblems() = %v", report.hasProblems())
+			}
+		})
+	}
+}
+
+func TestCheckForDuplicatesOverExpected(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	planned := mustPlan(t, api.WorklogPlan{WorkType: api.CapitalizableWorkType, Seconds: 60 * 3600, StartDay: monday, AccountID: "acct", IssueID: "10001"})
+
+	tests := []struct {
+		name            string
+		expectedSeconds int
+		wantErr         bool
+	}{
+		{name: "planned time alone goes over the week", expectedSeconds: 40 * 3600, wantErr: true},
+		{name: "planned time fills the week", expectedSeconds: 60 * 3600, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No answer is given, so any question asked declines the submission
+			p := NewPrompter(strings.NewReader(""), io.Discard)
+			err := checkForDuplicates(p, nil, monday, planned, tt.expectedSeconds, newIssueResolver(nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkForDuplicates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
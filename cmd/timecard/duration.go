@@ -0,0 +1,106 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const DAY_LENGTH_CONFIG = TOP_LEVEL_CONFIG + ".dayLength"
+const ROUNDING_INCREMENT_CONFIG = TOP_LEVEL_CONFIG + ".roundingIncrement"
+
+const defaultDayLength = 8 * time.Hour
+
+// dayPrefix matches a leading day count such as "1d" or "0.5d" in a duration string.
+var dayPrefix = regexp.MustCompile(`^(\d+(?:\.\d+)?)d`)
+
+// parseTimeInput converts a time answer into seconds. Plain numbers are hours ("7.5"),
+// anything else is a duration such as "7h30m", "45m" or "1d", where a day is dayLength long.
+func parseTimeInput(input string, dayLength time.Duration) (int, error) {
+	original := strings.TrimSpace(input)
+	input = strings.ToLower(original)
+	if input == "" {
+		return 0, fmt.Errorf("time cannot be empty")
+	}
+
+	if hours, err := strconv.ParseFloat(input, 64); err == nil {
+		return checkSeconds(original, hours*3600)
+	}
+
+	var total time.Duration
+	if match := dayPrefix.FindStringSubmatch(input); match != nil {
+		days, _ := strconv.ParseFloat(match[1], 64)
+		total += time.Duration(days * float64(dayLength))
+		input = input[len(match[0]):]
+	}
+	if input != "" {
+		rest, err := time.ParseDuration(input)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q, use hours like 7.5 or a duration like 7h30m, 45m or 1d", original)
+		}
+		total += rest
+	}
+	return checkSeconds(original, total.Seconds())
+}
+
+func checkSeconds(input string, seconds float64) (int, error) {
+	if seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, fmt.Errorf("invalid time %q, time cannot be negative", input)
+	}
+	return int(math.Round(seconds)), nil
+}
+
+// configuredDayLength returns how long "1d" is, from config or the 8 hour default.
+func configuredDayLength() time.Duration {
+	return configuredDuration(DAY_LENGTH_CONFIG, defaultDayLength)
+}
+
+// configuredIncrement returns the increment hours are spread across days in.
+func configuredIncrement() time.Duration {
+	return configuredDuration(ROUNDING_INCREMENT_CONFIG, api.DefaultIncrement)
+}
+
+// configuredDuration returns the duration set under key, or fallback when it is unset or invalid.
+func configuredDuration(key string, fallback time.Duration) time.Duration {
+	duration, err := parseConfiguredDuration(key)
+	if err != nil || duration == 0 {
+		return fallback
+	}
+	return duration
+}
+
+// parseConfiguredDuration reads the positive duration set under key, or 0 when it is unset.
+func parseConfiguredDuration(key string) (time.Duration, error) {
+	value := viper.GetString(key)
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return duration, nil
+}
+
+// warnInvalidDurations tells out about each configured duration that is invalid and falls back to its default.
+func warnInvalidDurations(out io.Writer) {
+	durations := []struct {
+		key      string
+		fallback time.Duration
+	}{
+		{DAY_LENGTH_CONFIG, defaultDayLength},
+		{ROUNDING_INCREMENT_CONFIG, api.DefaultIncrement},
+	}
+	for _, duration := range durations {
+		if _, err := parseConfiguredDuration(duration.key); err != nil {
+			fmt.Fprintf(out, "⚠️  Ignoring %v, using %s\n", err, duration.fallback)
+		}
+	}
+}
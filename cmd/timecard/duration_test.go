@@ -0,0 +1,82 @@
+package timecard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestParseTimeInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		dayLength time.Duration
+		expected  int
+		wantErr   bool
+	}{
+		{name: "whole hours", input: "8", dayLength: 8 * time.Hour, expected: 8 * 3600},
+		{name: "fractional hours", input: "7.5", dayLength: 8 * time.Hour, expected: 27000},
+		{name: "zero", input: "0", dayLength: 8 * time.Hour, expected: 0},
+		{name: "hours and minutes", input: "7h30m", dayLength: 8 * time.Hour, expected: 27000},
+		{name: "minutes", input: "45m", dayLength: 8 * time.Hour, expected: 2700},
+		{name: "one day", input: "1d", dayLength: 8 * time.Hour, expected: 8 * 3600},
+		{name: "custom day length", input: "1d", dayLength: 7*time.Hour + 30*time.Minute, expected: 27000},
+		{name: "half day", input: "0.5d", dayLength: 8 * time.Hour, expected: 4 * 3600},
+		{name: "days and hours", input: "2d4h", dayLength: 8 * time.Hour, expected: 20 * 3600},
+		{name: "upper case", input: "1H", dayLength: 8 * time.Hour, expected: 3600},
+		{name: "surrounding whitespace", input: " 3 ", dayLength: 8 * time.Hour, expected: 3 * 3600},
+		{name: "empty", input: "", dayLength: 8 * time.Hour, wantErr: true},
+		{name: "negative hours", input: "-2", dayLength: 8 * time.Hour, wantErr: true},
+		{name: "negative duration", input: "-2h", dayLength: 8 * time.Hour, wantErr: true},
+		{name: "garbage", input: "lots", dayLength: 8 * time.Hour, wantErr: true},
+		{name: "unknown unit", input: "2w", dayLength: 8 * time.Hour, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeInput(tt.input, tt.dayLength)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseTimeInput(%q) = %d, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTimeInput(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("parseTimeInput(%q) = %d, want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfiguredDurations(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if got := configuredDayLength(); got != 8*time.Hour {
+		t.Errorf("configuredDayLength() default = %v, want 8h", got)
+	}
+	if got := configuredIncrement(); got != 15*time.Minute {
+		t.Errorf("configuredIncrement() default = %v, want 15m", got)
+	}
+
+	viper.Set(DAY_LENGTH_CONFIG, "7h30m")
+	viper.Set(ROUNDING_INCREMENT_CONFIG, "bogus")
+	if got := configuredDayLength(); got != 7*time.Hour+30*time.Minute {
+		t.Errorf("configuredDayLength() = %v, want 7h30m", got)
+	}
+	if got := configuredIncrement(); got != 15*time.Minute {
+		t.Errorf("configuredIncrement() with invalid config = %v, want default", got)
+	}
+
+	var out bytes.Buffer
+	warnInvalidDurations(&out)
+	if got := out.String(); !strings.Contains(got, ROUNDING_INCREMENT_CONFIG) || strings.Contains(got, DAY_LENGTH_CONFIG) {
+		t.Errorf("warnInvalidDurations() = %q, want a warning about %s only", got, ROUNDING_INCREMENT_CONFIG)
+	}
+}
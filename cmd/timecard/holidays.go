@@ -0,0 +1,126 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+const HOLIDAYS_CONFIG = TOP_LEVEL_CONFIG + ".holidays"
+const HOLIDAY_REGION_CONFIG = HOLIDAYS_CONFIG + ".region"
+const HOLIDAY_LOG_AS_CONFIG = HOLIDAYS_CONFIG + ".logAs"
+
+// loadHolidays reads the holiday calendar for the configured region. Each region lists its
+// holidays as dates, points at an ICS file, or both. No region means no holidays.
+func loadHolidays() (calendar.Holidays, error) {
+	region := viper.GetString(HOLIDAY_REGION_CONFIG)
+	if region == "" {
+		return calendar.Holidays{}, nil
+	}
+
+	regionKey := HOLIDAYS_CONFIG + ".regions." + region
+	if !viper.IsSet(regionKey) {
+		return nil, fmt.Errorf("holiday region %q is not defined under %s.regions", region, HOLIDAYS_CONFIG)
+	}
+
+	holidays, err := calendar.ParseDateList(viper.GetStringSlice(regionKey + ".dates"))
+	if err != nil {
+		return nil, fmt.Errorf("holiday region %q: %w", region, err)
+	}
+
+	if icsPath := viper.GetString(regionKey + ".ics"); icsPath != "" {
+		fromFile, err := calendar.LoadICS(expandHome(icsPath))
+		if err != nil {
+			return nil, fmt.Errorf("holiday region %q: %w", region, err)
+		}
+		for date, name := range fromFile {
+			if _, ok := holidays[date]; !ok {
+				holidays[date] = name
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
+// workingDays returns the working days of the week starting at startOfWeek, skipping holidays.
+func workingDays(startOfWeek time.Time, week calendar.Week, holidays calendar.Holidays) []time.Time {
+	days := []time.Time{}
+	for _, day := range week.WorkingDaysFrom(startOfWeek) {
+		if !holidays.Contains(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// holidaysInWeek returns the holidays that fall on working days of the week starting at startOfWeek.
+func holidaysInWeek(startOfWeek time.Time, week calendar.Week, holidays calendar.Holidays) []time.Time {
+	days := []time.Time{}
+	for _, day := range week.WorkingDaysFrom(startOfWeek) {
+		if holidays.Contains(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// planHolidays logs a full day for every holiday against the category named by holidays.logAs.
+// A day is as long as the schedule requires, or the configured day length when the schedule has no hours for it.
+// Nothing is planned when logAs is not configured.
+func planHolidays(out io.Writer, categories []timeCategory, days []time.Time, holidays calendar.Holidays, schedule weekSchedule, describer describer, accountId, issueId string) ([]*api.WorklogRequest, error) {
+	logAs := viper.GetString(HOLIDAY_LOG_AS_CONFIG)
+	if logAs == "" || len(days) == 0 {
+		return nil, nil
+	}
+
+	for _, category := range categories {
+		if category.Name != logAs {
+			continue
+		}
+		var planned []*api.WorklogRequest
+		for _, day := range days {
+			dayLength := schedule.requiredOn(day)
+			if dayLength == 0 {
+				dayLength = int(configuredDayLength() / time.Second)
+			}
+			fmt.Fprintf(out, "🎉 %s is %s, logging %s as %s\n", day.Format(time.DateOnly), holidays.Name(day), api.FormatHours(dayLength), category.Name)
+			entries, err := api.PlanWorklog(api.WorklogPlan{
+				WorkType:    category.workType(),
+				Seconds:     dayLength,
+				StartDay:    day,
+				AccountID:   accountId,
+				IssueID:     category.issueOr(issueId),
+				Days:        []time.Time{day},
+				Distributor: api.FillDays{DayLength: time.Duration(dayLength) * time.Second},
+			})
+			if err != nil {
+				return nil, err
+			}
+			planned = append(planned, entries...)
+		}
+		if err := describer.describe(planned, category); err != nil {
+			return nil, err
+		}
+		return planned, nil
+	}
+	return nil, fmt.Errorf("%s is %q, which is not a configured category", HOLIDAY_LOG_AS_CONFIG, logAs)
+}
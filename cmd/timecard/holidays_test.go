@@ -0,0 +1,100 @@
+package timecard
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+func TestLoadHolidays(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	holidays, err := loadHolidays()
+	if err != nil || len(holidays) != 0 {
+		t.Fatalf("loadHolidays() without a region = %v, %v; want none", holidays, err)
+	}
+
+	icsFile := filepath.Join(t.TempDir(), "uk.ics")
+	ics := "BEGIN:VEVENT\nDTSTART;VALUE=DATE:20260831\nSUMMARY:Summer Bank Holiday\nEND:VEVENT\n"
+	if err := os.WriteFile(icsFile, []byte(ics), 0644); err != nil {
+		t.Fatalf("failed to write ics: %v", err)
+	}
+
+	viper.Set(HOLIDAY_REGION_CONFIG, "uk")
+	viper.Set(HOLIDAYS_CONFIG+".regions.uk.dates", []string{"2026-12-25 Christmas Day"})
+	viper.Set(HOLIDAYS_CONFIG+".regions.uk.ics", icsFile)
+
+	holidays, err = loadHolidays()
+	if err != nil {
+		t.Fatalf("loadHolidays() error = %v", err)
+	}
+	if holidays["2026-12-25"] != "Christmas Day" || holidays["2026-08-31"] != "Summer Bank Holiday" {
+		t.Errorf("loadHolidays() = %v, want dates and ics combined", holidays)
+	}
+
+	viper.Set(HOLIDAY_REGION_CONFIG, "fr")
+	if _, err := loadHolidays(); err == nil || !strings.Contains(err.Error(), "fr") {
+		t.Errorf("expected error for undefined region, got %v", err)
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	monday := time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC)
+	holidays := map[string]string{"2026-12-25": "Christmas Day", "2026-12-26": "Boxing Day"}
+
+	days := workingDays(monday, calendar.DefaultWeek(), holidays)
+	if len(days) != 4 {
+		t.Fatalf("got %d working days, want 4", len(days))
+	}
+	for _, day := range days {
+		if day.Weekday() == time.Friday {
+			t.Error("Christmas Day should not be a working day")
+		}
+	}
+
+	inWeek := holidaysInWeek(monday, calendar.DefaultWeek(), holidays)
+	if len(inWeek) != 1 || inWeek[0].Format(time.DateOnly) != "2026-12-25" {
+		t.Errorf("holidaysInWeek() = %v, want only the weekday holiday", inWeek)
+	}
+}
+
+func TestPlanHolidays(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	holidays := map[string]string{"2026-12-25": "Christmas Day"}
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+	categories := defaultCategories()
+
+	planned, err := planHolidays(io.Discard, categories, []time.Time{christmas}, holidays, weekSchedule{}, describer{}, "acct", "10001")
+	if err != nil || len(planned) != 0 {
+		t.Fatalf("planHolidays() without logAs = %v, %v; want nothing", planned, err)
+	}
+
+	viper.Set(HOLIDAY_LOG_AS_CONFIG, ptoCategory)
+	planned, err = planHolidays(io.Discard, categories, []time.Time{christmas}, holidays, weekSchedule{}, describer{}, "acct", "10001")
+	if err != nil {
+		t.Fatalf("planHolidays() error = %v", err)
+	}
+	if len(planned) != 1 || planned[0].StartDate != "2026-12-25" || planned[0].TimeSpentSeconds != 8*3600 || planned[0].Attributes[0].Value != "20E" {
+		t.Errorf("planHolidays() = %+v, want a full PTO day on Christmas", planned)
+	}
+
+	partTime := weekSchedule{required: map[string]int{"2026-12-25": 6 * 3600}}
+	planned, err = planHolidays(io.Discard, categories, []time.Time{christmas}, holidays, partTime, describer{}, "acct", "10001")
+	if err != nil || len(planned) != 1 || planned[0].TimeSpentSeconds != 6*3600 {
+		t.Errorf("planHolidays() with schedule = %+v, %v; want the scheduled 6 hours", planned, err)
+	}
+
+	viper.Set(HOLIDAY_LOG_AS_CONFIG, "vacation")
+	if _, err := planHolidays(io.Discard, categories, []time.Time{christmas}, holidays, weekSchedule{}, describer{}, "acct", "10001"); err == nil {
+		t.Error("expected error for unknown logAs category")
+	}
+}
@@ -0,0 +1,144 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const JIRA_URL_CONFIG = TOP_LEVEL_CONFIG + ".jira.url"
+const JIRA_EMAIL_CONFIG = TOP_LEVEL_CONFIG + ".jira.email"
+
+// JIRA_SITE_TOKEN_NAME is the keychain entry for the Jira site API token. The Tempo token predates it
+// and is stored as API_TOKEN_NAME.
+const JIRA_SITE_TOKEN_NAME = "jira-site-api-token"
+
+// checkIssueID reports whether id is a numeric issue ID or a Jira issue key such as PROJ-123.
+func checkIssueID(id string) error {
+	if _, err := strconv.Atoi(id); err == nil || api.IsIssueKey(id) {
+		return nil
+	}
+	return fmt.Errorf("invalid issue %q, expected a key such as PROJ-123 or a numeric issue ID", id)
+}
+
+// issueResolver translates between Jira issue keys and the numeric IDs Tempo uses, remembering each
+// lookup. A nil resolver, or one without a Jira site, only understands numeric IDs.
+type issueResolver struct {
+	jira *api.JiraClient
+	// keys maps issue IDs to keys and ids maps keys to IDs
+	keys map[string]string
+	ids  map[string]string
+}
+
+func newIssueResolver(jira *api.JiraClient) *issueResolver {
+	return &issueResolver{jira: jira, keys: map[string]string{}, ids: map[string]string{}}
+}
+
+// configuredIssueResolver builds a resolver for the configured Jira site, if there is one, warning on out
+// when the site's token is missing.
+func configuredIssueResolver(out io.Writer) *issueResolver {
+	siteURL := viper.GetString(JIRA_URL_CONFIG)
+	if siteURL == "" {
+		return newIssueResolver(nil)
+	}
+	token, err := readSecret(SECRETS_NAMESPACE, JIRA_SITE_TOKEN_NAME)
+	if err != nil || token == "" {
+		fmt.Fprintln(out, "⚠️  No Jira API token is saved, so issue keys cannot be looked up. Run configure to add one.")
+		return newIssueResolver(nil)
+	}
+	return newIssueResolver(api.NewJiraClient(siteURL, viper.GetString(JIRA_EMAIL_CONFIG), token, api.WithTimeout(tempoRequestTimeout)))
+}
+
+// remember records an issue's ID and key.
+func (r *issueResolver) remember(issue *api.JiraIssue) {
+	r.keys[issue.ID] = issue.Key
+	r.ids[issue.Key] = issue.ID
+}
+
+// id returns the numeric issue ID for ref, which is either an ID already or a Jira issue key.
+func (r *issueResolver) id(ref string) (string, error) {
+	if err := checkIssueID(ref); err != nil {
+		return "", err
+	}
+	if !api.IsIssueKey(ref) {
+		return ref, nil
+	}
+	if r == nil || r.jira == nil {
+		return "", fmt.Errorf("issue %s is a key, which needs a Jira site: run configure to add one or use a numeric issue ID", ref)
+	}
+	if id, ok := r.ids[ref]; ok {
+		return id, nil
+	}
+
+	issue, err := r.jira.GetIssue(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up issue %s: %w", ref, err)
+	}
+	r.remember(issue)
+	return issue.ID, nil
+}
+
+// key returns the Jira key for an issue ID, or the ID itself when it cannot be looked up.
+func (r *issueResolver) key(id string) string {
+	if r == nil || r.jira == nil || api.IsIssueKey(id) {
+		return id
+	}
+	if key, ok := r.keys[id]; ok {
+		return key
+	}
+
+	issue, err := r.jira.GetIssue(id)
+	if err != nil {
+		// Remember the miss so an unreachable site is not asked again for every worklog
+		r.keys[id] = id
+		return id
+	}
+	r.remember(issue)
+	return issue.Key
+}
+
+// configureJira asks for the Jira site used to look up issue keys. A blank site skips Jira entirely.
+// Without a terminal nothing is asked, and settings that were not passed keep their current values.
+func configureJira(p *Prompter, siteURL, email, token string) {
+	var err error
+	if siteURL == "" && !p.interactive {
+		siteURL = viper.GetString(JIRA_URL_CONFIG)
+	} else if siteURL == "" {
+		siteURL, err = p.Ask("Jira site URL for looking up issue keys, e.g. https://example.atlassian.net (blank to skip):", viper.GetString(JIRA_URL_CONFIG), nil)
+		p.exitOnError(err)
+	}
+	if siteURL == "" {
+		return
+	}
+	viper.Set(JIRA_URL_CONFIG, strings.TrimRight(siteURL, "/"))
+
+	if email == "" && !p.interactive {
+		email = viper.GetString(JIRA_EMAIL_CONFIG)
+	} else if email == "" {
+		email, err = p.Ask("Atlassian account email:", viper.GetString(JIRA_EMAIL_CONFIG), nil)
+		p.exitOnError(err)
+	}
+	viper.Set(JIRA_EMAIL_CONFIG, email)
+
+	if token == "" {
+		if saved, err := readSecret(SECRETS_NAMESPACE, JIRA_SITE_TOKEN_NAME); err == nil && saved != "" {
+			return
+		}
+		if p.interactive {
+			token, err = p.Ask("Enter your Jira API token:", "", nil)
+			p.exitOnError(err)
+		}
+	}
+	if token == "" {
+		fmt.Fprintln(p.out, "No Jira API token given, issue keys cannot be looked up until one is configured.")
+		return
+	}
+	if err := writeSecret(SECRETS_NAMESPACE, JIRA_SITE_TOKEN_NAME, token); err != nil {
+		p.exitOnError(fmt.Errorf("Failed to write Jira token to keychain: %w", err))
+	}
+	fmt.Fprintln(p.out, "Jira API token saved securely to keychain.")
+}
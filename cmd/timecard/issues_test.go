@@ -0,0 +1,91 @@
+package timecard
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/danlafeir/devctl-timecard/api"
+)
+
+// fakeJira serves the two issues PROJ-12 (10012) and PROJ-40 (10040) and counts lookups.
+func fakeJira(t *testing.T, lookups *int) *api.JiraClient {
+	t.Helper()
+	issues := map[string]string{
+		"PROJ-12": `{"id":"10012","key":"PROJ-12"}`,
+		"10012":   `{"id":"10012","key":"PROJ-12"}`,
+		"PROJ-40": `{"id":"10040","key":"PROJ-40"}`,
+		"10040":   `{"id":"10040","key":"PROJ-40"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*lookups++
+		body, ok := issues[r.URL.Path[len("/rest/api/3/issue/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return api.NewJiraClient(server.URL, "me@example.com", "token", api.WithMaxAttempts(1))
+}
+
+func TestIssueResolver(t *testing.T) {
+	lookups := 0
+	resolver := newIssueResolver(fakeJira(t, &lookups))
+
+	id, err := resolver.id("PROJ-12")
+	if err != nil || id != "10012" {
+		t.Fatalf("id(PROJ-12) = %q, %v; want 10012", id, err)
+	}
+	if key := resolver.key("10012"); key != "PROJ-12" {
+		t.Errorf("key(10012) = %q, want PROJ-12", key)
+	}
+	if lookups != 1 {
+		t.Errorf("made %d lookups, want the key and ID to be remembered after 1", lookups)
+	}
+
+	if key := resolver.key("10040"); key != "PROJ-40" {
+		t.Errorf("key(10040) = %q, want PROJ-40", key)
+	}
+	if id, err := resolver.id("10099"); err != nil || id != "10099" {
+		t.Errorf("id(10099) = %q, %v; want numeric IDs passed through", id, err)
+	}
+	if key := resolver.key("10099"); key != "10099" {
+		t.Errorf("key(10099) = %q, want the ID when it cannot be looked up", key)
+	}
+	if _, err := resolver.id("PROJ-99"); err == nil {
+		t.Error("expected error for an unknown key")
+	}
+	if _, err := resolver.id("not an issue"); err == nil {
+		t.Error("expected error for an invalid issue")
+	}
+}
+
+func TestIssueResolverWithoutJira(t *testing.T) {
+	var resolver *issueResolver
+	if key := resolver.key("10012"); key != "10012" {
+		t.Errorf("key(10012) = %q, want the ID", key)
+	}
+	if _, err := resolver.id("PROJ-12"); err == nil {
+		t.Error("expected error resolving a key without a Jira site")
+	}
+	if _, err := newIssueResolver(nil).id("PROJ-12"); err == nil {
+		t.Error("expected error resolving a key without a Jira site")
+	}
+}
+
+func TestIssueSharesWithKeys(t *testing.T) {
+	lookups := 0
+	resolver := newIssueResolver(fakeJira(t, &lookups))
+
+	issues, split, err := issueShares(io.Discard, timeCategory{Name: capitalizableCategory}, "PROJ-12=60%,PROJ-40=40%", "10001", 10*3600, resolver)
+	if err != nil {
+		t.Fatalf("issueShares() error = %v", err)
+	}
+	if !reflect.DeepEqual(issues, []string{"10012", "10040"}) || !reflect.DeepEqual(split, []int{6 * 3600, 4 * 3600}) {
+		t.Errorf("issueShares() = %v %v, want the keys resolved to IDs", issues, split)
+	}
+}
@@ -0,0 +1,48 @@
+package timecard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const DAY_START_CONFIG = TOP_LEVEL_CONFIG + ".dayStart"
+const LUNCH_START_CONFIG = TOP_LEVEL_CONFIG + ".lunch.start"
+const LUNCH_LENGTH_CONFIG = TOP_LEVEL_CONFIG + ".lunch.length"
+
+// configuredDayLayout reads when worklogs start each day and the optional lunch break they are laid out around.
+func configuredDayLayout() (api.DayLayout, error) {
+	layout := api.DefaultDayLayout
+	if viper.IsSet(DAY_START_CONFIG) {
+		start, err := parseClock(viper.GetString(DAY_START_CONFIG))
+		if err != nil {
+			return api.DayLayout{}, fmt.Errorf("invalid %s: %w", DAY_START_CONFIG, err)
+		}
+		layout.Start = start
+	}
+
+	if viper.IsSet(LUNCH_LENGTH_CONFIG) {
+		lunchStart, err := parseClock(viper.GetString(LUNCH_START_CONFIG))
+		if err != nil {
+			return api.DayLayout{}, fmt.Errorf("invalid %s: %w", LUNCH_START_CONFIG, err)
+		}
+		lunchLength, err := time.ParseDuration(viper.GetString(LUNCH_LENGTH_CONFIG))
+		if err != nil || lunchLength < 0 {
+			return api.DayLayout{}, fmt.Errorf("invalid %s %q, expected a duration such as 45m", LUNCH_LENGTH_CONFIG, viper.GetString(LUNCH_LENGTH_CONFIG))
+		}
+		layout.LunchStart = lunchStart
+		layout.LunchLength = lunchLength
+	}
+	return layout, nil
+}
+
+// parseClock turns a time of day such as "08:30" into an offset from midnight.
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day such as 09:00", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
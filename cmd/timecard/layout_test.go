@@ -0,0 +1,57 @@
+package timecard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+func TestConfiguredDayLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]string
+		expected api.DayLayout
+		wantErr  bool
+	}{
+		{name: "defaults", expected: api.DefaultDayLayout},
+		{
+			name:     "day start",
+			config:   map[string]string{DAY_START_CONFIG: "08:30"},
+			expected: api.DayLayout{Start: 8*time.Hour + 30*time.Minute},
+		},
+		{
+			name:     "lunch break",
+			config:   map[string]string{LUNCH_START_CONFIG: "12:30", LUNCH_LENGTH_CONFIG: "45m"},
+			expected: api.DayLayout{Start: 9 * time.Hour, LunchStart: 12*time.Hour + 30*time.Minute, LunchLength: 45 * time.Minute},
+		},
+		{name: "invalid day start", config: map[string]string{DAY_START_CONFIG: "9am"}, wantErr: true},
+		{name: "lunch without start", config: map[string]string{LUNCH_LENGTH_CONFIG: "1h"}, wantErr: true},
+		{name: "invalid lunch length", config: map[string]string{LUNCH_START_CONFIG: "12:00", LUNCH_LENGTH_CONFIG: "an hour"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for key, value := range tt.config {
+				viper.Set(key, value)
+			}
+
+			got, err := configuredDayLayout()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("configuredDayLayout() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,7 +2,8 @@ package timecard
 
 import (
 	"fmt"
-	"os"
+	"strings"
+	"time"
 
 	"github.com/danlafeir/devctl-timecard/api"
 	"github.com/spf13/cobra"
@@ -12,84 +13,275 @@ import (
 func ConfigureCmd() *cobra.Command {
 	var apiToken string
 	var accountId string
+	var baseURL string
+	var jiraURL, jiraEmail, jiraToken string
+	var issue string
 
 	configureCmd := &cobra.Command{
 		Use:   "configure",
 		Short: "Configure integration with timesheet tool (currently just Tempo)",
 		Run: func(cmd *cobra.Command, args []string) {
+			prompter := commandPrompter(cmd)
 			initConfig()
 			viper.ReadInConfig()
 
-			configureApiToken(apiToken)
-			configureAccountId(accountId)
+			if baseURL != "" {
+				viper.Set(BASE_URL_CONFIG, baseURL)
+			}
+			token := configureApiToken(prompter, apiToken)
+			configureAccountId(prompter, accountId)
 			// Get accountId from viper after it's been set
 			configuredAccountId := viper.GetString("tempo." + ACCOUNT_ID_CONFIG)
 			if configuredAccountId == "" {
 				configuredAccountId = accountId
 			}
-			configureIssueId(configuredAccountId)
+			configureJira(prompter, jiraURL, jiraEmail, jiraToken)
+			configureIssueId(prompter, configuredAccountId, issue)
+			categories, err := loadCategories()
+			prompter.exitOnError(err)
+			configureWorkTypes(prompter, newTempoClient(token), categories)
+			// --issue becomes every category's default without asking about each one
+			if issue == "" {
+				configureCategoryIssues(prompter, categories, viper.GetString(ISSUE_ID_CONFIG), configuredIssueResolver(cmd.OutOrStdout()))
+			}
 
 			if err := viper.WriteConfig(); err != nil {
-				fmt.Println("Failed to save config:", err)
-				os.Exit(1)
+				prompter.exitOnError(fmt.Errorf("Failed to save config: %w", err))
 			}
-			fmt.Println("Configuration saved successfully.")
+			fmt.Fprintln(cmd.OutOrStdout(), "Configuration saved successfully.")
 		},
 	}
 	configureCmd.Flags().StringVar(&apiToken, "token", "", "Tempo API token")
 	configureCmd.Flags().StringVar(&accountId, "account-id", "", "Tempo account ID")
+	configureCmd.Flags().StringVar(&baseURL, "base-url", "", "Tempo API base URL (defaults to "+api.DefaultBaseURL+")")
+	configureCmd.Flags().StringVar(&issue, "issue", "", "Default issue key or ID, skipping the recent issue picker")
+	configureCmd.Flags().StringVar(&jiraURL, "jira-url", "", "Jira site URL used to look up issue keys, e.g. https://example.atlassian.net")
+	configureCmd.Flags().StringVar(&jiraEmail, "jira-email", "", "Atlassian account email for the Jira site")
+	configureCmd.Flags().StringVar(&jiraToken, "jira-token", "", "Jira API token")
 	return configureCmd
 }
 
 func AddEntryCmd() *cobra.Command {
-	var capitalizableTime, ptoTime, otherTime int
+	var atomic, force, notes, yes bool
+	var distribute, description, saveAlloc, weekSelector, overrideReason string
+	var allocs []string
+
+	var categories []timeCategory
+	var categoryTimes, categoryIssues []string
 
 	cmd := &cobra.Command{
 		Use:     "add-week",
 		Short:   "Add a timecard entry for a week of time",
-		Example: "timecard add-week",
+		Example: "timecard add-week\n  timecard add-week --week last --capitalizable-time 32 --pto-time 8 --other-time 0",
+		// Category flags come from config, so flags are parsed in PreRunE once it has been read
+		DisableFlagParsing: true,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			readConfigQuietly()
+			var err error
+			if categories, err = loadCategories(); err != nil {
+				return err
+			}
+			categoryTimes, categoryIssues = addCategoryFlags(cmd, categories)
+			return cmd.Flags().Parse(args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			bearerToken := fetchBearerToken()
-			accountId, issueId := fetchConfig()
-			startOfWeek := requestDayOfWeek()
-
-			// Use CLI flags if provided, otherwise prompt interactively
-			hasAnyFlag := cmd.Flags().Changed("capitalizable-time") || cmd.Flags().Changed("pto-time") || cmd.Flags().Changed("other-time")
-
-			if !hasAnyFlag {
-				// No flags provided, use interactive prompts for all
-				capitalizableTime, ptoTime, otherTime = requestTimeInput()
-			} else {
-				// Some or all flags provided - use flags for set values, prompt for missing ones
-				if !cmd.Flags().Changed("capitalizable-time") {
-					capitalizableTime = getTime(CapitalizableTime)
+			if help, _ := cmd.Flags().GetBool("help"); help {
+				return cmd.Help()
+			}
+			prompter := commandPrompter(cmd)
+			out := cmd.OutOrStdout()
+			accountId, issueId, err := fetchConfig(prompter)
+			if err != nil {
+				return err
+			}
+			for i, category := range categories {
+				if !cmd.Flags().Changed(category.issueFlagName()) {
+					continue
+				}
+				if err := checkIssueID(categoryIssues[i]); err != nil {
+					return fmt.Errorf("--%s: %w", category.issueFlagName(), err)
+				}
+				// An issue given for this run replaces the category's configured issue and allocation
+				categories[i].IssueID = categoryIssues[i]
+				categories[i].Allocation = ""
+			}
+			resolver := configuredIssueResolver(out)
+			issueId, err = resolveIssues(resolver, issueId, categories)
+			if err != nil {
+				return err
+			}
+			allocations, err := allocationFlags(allocs, categories)
+			if err != nil {
+				return err
+			}
+			if saveAlloc != "" {
+				if len(allocs) != 1 {
+					return fmt.Errorf("--save-alloc needs exactly one --alloc to save")
 				}
-				if !cmd.Flags().Changed("pto-time") {
-					ptoTime = getTime(PtoTime)
+				for _, spec := range allocations {
+					if err := saveAllocation(saveAlloc, spec); err != nil {
+						return err
+					}
 				}
-				if !cmd.Flags().Changed("other-time") {
-					otherTime = getTime(OtherTime)
+				if err := viper.WriteConfig(); err != nil {
+					return fmt.Errorf("failed to save allocation: %w", err)
 				}
+				fmt.Fprintf(out, "💾 Saved allocation %q\n", saveAlloc)
+			}
+			for _, spec := range allocations {
+				if _, err := resolveAllocation(spec); err != nil {
+					return fmt.Errorf("--alloc: %w", err)
+				}
+			}
+			holidays, err := loadHolidays()
+			if err != nil {
+				return err
+			}
+			week, err := loadWeek()
+			if err != nil {
+				return err
+			}
+			layout, err := configuredDayLayout()
+			if err != nil {
+				return err
+			}
+			policy, err := loadWeekPolicy(categories)
+			if err != nil {
+				return err
 			}
 
-			if err := api.SendWorklog(api.CapitalizableWorkType, capitalizableTime, startOfWeek, bearerToken, accountId, issueId); err != nil {
+			// Use CLI flags for categories that were given, prompt interactively for the rest
+			provided := map[string]int{}
+			for i, category := range categories {
+				if !cmd.Flags().Changed(category.flagName()) {
+					continue
+				}
+				seconds, err := parseTimeInput(categoryTimes[i], configuredDayLength())
+				if err != nil {
+					return fmt.Errorf("--%s: %w", category.flagName(), err)
+				}
+				provided[category.Name] = seconds
+			}
+			if missing := missingCategoryFlags(categories, provided); len(missing) > 0 {
+				if err := prompter.require("for the remaining time", "pass "+strings.Join(missing, ", ")); err != nil {
+					return err
+				}
+			}
+			promptNotes := notes || viper.GetBool(PROMPT_NOTES_CONFIG)
+			if promptNotes {
+				if err := prompter.require("for notes", "drop --notes or turn off "+PROMPT_NOTES_CONFIG); err != nil {
+					return err
+				}
+			}
+
+			client := newTempoClient(fetchBearerToken(prompter))
+			startOfWeek, err := chooseWeek(prompter, weekSelector, yes, week)
+			if err != nil {
+				return err
+			}
+
+			fullSchedule := fetchSchedule(out, client, accountId, startOfWeek, week)
+			schedule := fullSchedule.withoutHolidays(holidays)
+			weekHolidays := holidaysInWeek(startOfWeek, week, holidays)
+			for _, day := range weekHolidays {
+				fmt.Fprintf(out, "📅 Not spreading time onto %s (%s)\n", day.Format(time.DateOnly), holidays.Name(day))
+			}
+
+			seconds, err := requestTimeInput(prompter, categories, provided, schedule.requiredTotal())
+			if err != nil {
+				return err
+			}
+
+			planner := weekPlanner{
+				accountId:   accountId,
+				issueId:     issueId,
+				categories:  categories,
+				allocations: allocations,
+				distribute:  distribute,
+				holidays:    holidays,
+				week:        week,
+				describer: describer{
+					override: description,
+					fallback: viper.GetString(DESCRIPTION_CONFIG),
+					holidays: holidays,
+					issues:   resolver,
+				},
+				out: out,
+			}
+			if err := planner.describer.validate(); err != nil {
+				return err
+			}
+			var notesByDay map[string]string
+			if promptNotes {
+				if notesByDay, err = requestNotes(prompter, schedule.days); err != nil {
+					return err
+				}
+			}
+
+			planned, err := planner.plan(startOfWeek, fullSchedule, seconds, notesByDay)
+			if err != nil {
 				return err
 			}
-			if err := api.SendWorklog(api.PtoWorkType, ptoTime, startOfWeek, bearerToken, accountId, issueId); err != nil {
+
+			existing, err := client.GetUserWorklogs(accountId, startOfWeek, startOfWeek.AddDate(0, 0, daysPerWeek-1))
+			if err != nil {
+				if !force {
+					return fmt.Errorf("failed to check existing worklogs (use --force to skip this check): %w", err)
+				}
+				fmt.Fprintf(out, "⚠️  Could not fetch existing worklogs, new ones may overlap them: %v\n", err)
+			}
+			if !force {
+				if err := checkForDuplicates(prompter, existing, startOfWeek, planned, expectedSchedule(fullSchedule, holidays).requiredTotal(), resolver); err != nil {
+					return err
+				}
+			}
+
+			overridden, err := enforceViolations(out, policy.check(tallyWeek(categories, planned, existing)), overrideReason)
+			if err != nil {
 				return err
 			}
-			if err := api.SendWorklog(api.OtherWorkType, otherTime, startOfWeek, bearerToken, accountId, issueId); err != nil {
+			if overridden {
+				recordOverride(planned, overrideReason)
+			}
+
+			planned, err = layout.Layout(planned, existing)
+			if err != nil {
 				return err
 			}
 
-			fmt.Println("✅ All time entries submitted successfully!")
+			sub := newSubmission(client, out)
+			sub.issues = resolver
+			sub.prompter = prompter
+			if err := sub.send(planned); err != nil {
+				return sub.handleFailure(err, atomic || yes)
+			}
+
+			fmt.Fprintln(out, "✅ All time entries submitted successfully!")
 			return nil
 		},
 	}
 
-	cmd.Flags().IntVarP(&capitalizableTime, "capitalizable-time", "c", 0, "Capitalizable time in hours")
-	cmd.Flags().IntVarP(&ptoTime, "pto-time", "p", 0, "PTO time in hours")
-	cmd.Flags().IntVarP(&otherTime, "other-time", "m", 0, "Other time in hours")
+	cmd.Flags().StringVar(&weekSelector, "week", "", "Week to fill out without asking: "+weekSelectorHelp)
+	cmd.Flags().BoolVar(&yes, "yes", false, "Skip confirmations: fill out this week unless --week is given, and roll back on a failed submission")
+	cmd.Flags().StringVar(&distribute, "distribute", "", "How to spread time across the week for every category: front-loaded, even, back-loaded, fill-days or cap:<duration>")
+	cmd.Flags().BoolVar(&force, "force", false, "Submit even if the week already has matching worklogs or would exceed the expected hours")
+	cmd.Flags().BoolVar(&atomic, "atomic", false, "Automatically delete worklogs created in this run if any submission fails")
+	cmd.Flags().StringVar(&description, "description", "", "Description template for every worklog in this run, e.g. \"{{.Category}} on {{.Day}}\"")
+	cmd.Flags().BoolVar(&notes, "notes", false, "Prompt for an optional note on each working day")
+	cmd.Flags().StringVar(&overrideReason, "override-reason", "", "Submit even though validation rules fail, recording this reason in the worklog descriptions")
+	cmd.Flags().StringArrayVar(&allocs, "alloc", nil, "Split a category across issues, e.g. 10012=60%,10040=40% or pto:10050=100%; may be a saved allocation name")
+	cmd.Flags().StringVar(&saveAlloc, "save-alloc", "", "Save the --alloc allocation under this name for later runs")
 
 	return cmd
 }
+
+// addCategoryFlags registers the time and issue flags for each category, returning the values they are parsed into.
+func addCategoryFlags(cmd *cobra.Command, categories []timeCategory) ([]string, []string) {
+	times := make([]string, len(categories))
+	issues := make([]string, len(categories))
+	for i, category := range categories {
+		cmd.Flags().StringVarP(&times[i], category.flagName(), category.Shorthand, "", fmt.Sprintf("Time for the %s category, in hours (7.5) or as a duration (7h30m, 45m, 1d)", category.Name))
+		cmd.Flags().StringVar(&issues[i], category.issueFlagName(), "", fmt.Sprintf("Issue key or ID to log %s time against for this run", category.Name))
+	}
+	return times, issues
+}
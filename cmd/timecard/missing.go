@@ -0,0 +1,190 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// dayGap is one day's logged time against the time it requires.
+type dayGap struct {
+	day      time.Time
+	logged   int
+	required int
+}
+
+// weekGap is one week's logged time against the time it requires, with the days that differ.
+type weekGap struct {
+	start    time.Time
+	logged   int
+	required int
+	days     []dayGap
+}
+
+// short reports whether the week has less time logged than it requires.
+func (g weekGap) short() bool {
+	return g.logged < g.required
+}
+
+// compareWeek compares the worklogs logged during the week starting at startOfWeek with what schedule
+// requires. Days after until are left out of both sides so a week in progress is only checked so far.
+func compareWeek(startOfWeek time.Time, schedule weekSchedule, worklogs []api.WorklogResponse, until time.Time) weekGap {
+	logged := map[string]int{}
+	for _, worklog := range worklogs {
+		logged[worklog.StartDate] += worklog.TimeSpentSeconds
+	}
+
+	last := until.Format(time.DateOnly)
+	gap := weekGap{start: startOfWeek}
+	for day := 0; day < daysPerWeek; day++ {
+		date := startOfWeek.AddDate(0, 0, day)
+		key := date.Format(time.DateOnly)
+		if key > last {
+			break
+		}
+		required := schedule.requiredOn(date)
+		gap.logged += logged[key]
+		gap.required += required
+		if logged[key] != required {
+			gap.days = append(gap.days, dayGap{day: date, logged: logged[key], required: required})
+		}
+	}
+	return gap
+}
+
+// expectedSchedule returns the time each day of the week requires: holidays count only when they are
+// logged as a category, otherwise nothing is expected on them.
+func expectedSchedule(schedule weekSchedule, holidays calendar.Holidays) weekSchedule {
+	if viper.GetString(HOLIDAY_LOG_AS_CONFIG) != "" {
+		return schedule
+	}
+	return schedule.withoutHolidays(holidays)
+}
+
+// printGaps lists every week that is under or over what it requires, or has a day that is, broken down by
+// the days that differ. A week whose total adds up is still listed when its days do not.
+func printGaps(out io.Writer, gaps []weekGap) {
+	for _, gap := range gaps {
+		if gap.logged == gap.required && len(gap.days) == 0 {
+			continue
+		}
+		difference := "but some days differ"
+		if gap.logged != gap.required {
+			difference = describeDifference(gap.logged, gap.required)
+		}
+		fmt.Fprintf(out, "Week of %s: %g of %s logged, %s\n", gap.start.Format(time.DateOnly), float64(gap.logged)/3600, api.FormatHours(gap.required), difference)
+		for _, day := range gap.days {
+			fmt.Fprintf(out, "   %s %s  %g of %s, %s\n", day.day.Format("Mon"), day.day.Format(time.DateOnly), float64(day.logged)/3600, api.FormatHours(day.required), describeDifference(day.logged, day.required))
+		}
+	}
+}
+
+// describeDifference says how far logged is from required, e.g. "8 hours short".
+func describeDifference(logged, required int) string {
+	if logged < required {
+		return api.FormatHours(required-logged) + " short"
+	}
+	return api.FormatHours(logged-required) + " over"
+}
+
+// defaultRangeEnd is where a range of dates starting at from ends when no end is given. Today is usually not
+// logged yet, so it is yesterday, or from itself when from is today.
+func defaultRangeEnd(from time.Time, week calendar.Week) time.Time {
+	yesterday := week.Now().AddDate(0, 0, -1)
+	if yesterday.Before(from) {
+		return from
+	}
+	return yesterday
+}
+
+// missingRange parses the dates to check, which end at defaultRangeEnd unless until is given.
+func missingRange(since, until string, week calendar.Week) (time.Time, time.Time, error) {
+	sinceDate, err := week.ParseDate(since)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --since %q, expected YYYY-MM-DD: %w", since, err)
+	}
+	untilDate := defaultRangeEnd(sinceDate, week)
+	if until != "" {
+		if untilDate, err = week.ParseDate(until); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until %q, expected YYYY-MM-DD: %w", until, err)
+		}
+	}
+	if untilDate.Before(sinceDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--until %s is before --since %s", untilDate.Format(time.DateOnly), sinceDate.Format(time.DateOnly))
+	}
+	return sinceDate, untilDate, nil
+}
+
+func MissingCmd() *cobra.Command {
+	var since, until string
+
+	cmd := &cobra.Command{
+		Use:     "missing",
+		Short:   "List the weeks and days with less or more time logged than they require",
+		Example: "timecard missing --since 2026-09-01",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			week, err := loadWeek()
+			if err != nil {
+				return err
+			}
+			sinceDate, untilDate, err := missingRange(since, until, week)
+			if err != nil {
+				return err
+			}
+			holidays, err := loadHolidays()
+			if err != nil {
+				return err
+			}
+
+			prompter := commandPrompter(cmd)
+			accountId, _, err := fetchConfig(prompter)
+			if err != nil {
+				return err
+			}
+			client := newTempoClient(fetchBearerToken(prompter))
+			starts := weeksBetween(sinceDate, untilDate, week)
+			worklogs, err := client.GetUserWorklogs(accountId, starts[0], starts[len(starts)-1].AddDate(0, 0, daysPerWeek-1))
+			if err != nil {
+				return fmt.Errorf("failed to fetch worklogs: %w", err)
+			}
+			byWeek := map[string][]api.WorklogResponse{}
+			for _, worklog := range worklogs {
+				day, err := week.ParseDate(worklog.StartDate)
+				if err != nil {
+					continue
+				}
+				start := week.StartOf(day).Format(time.DateOnly)
+				byWeek[start] = append(byWeek[start], worklog)
+			}
+
+			var gaps []weekGap
+			short := 0
+			for _, start := range starts {
+				schedule := expectedSchedule(fetchSchedule(cmd.OutOrStdout(), client, accountId, start, week), holidays)
+				gap := compareWeek(start, schedule, byWeek[start.Format(time.DateOnly)], untilDate)
+				if gap.short() {
+					short++
+				}
+				gaps = append(gaps, gap)
+			}
+
+			printGaps(cmd.OutOrStdout(), gaps)
+			if short > 0 {
+				return fmt.Errorf("%d of %d week(s) since %s are missing time", short, len(gaps), sinceDate.Format(time.DateOnly))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ No week from %s to %s is missing time.\n", sinceDate.Format(time.DateOnly), untilDate.Format(time.DateOnly))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "First date to check (YYYY-MM-DD); its whole week is checked")
+	cmd.Flags().StringVar(&until, "until", "", "Last date to check (YYYY-MM-DD), defaults to yesterday")
+	cmd.MarkFlagRequired("since")
+
+	return cmd
+}
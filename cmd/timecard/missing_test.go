@@ -0,0 +1,134 @@
+package timecard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+func TestCompareWeek(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	monday := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	schedule := defaultSchedule(monday, calendar.DefaultWeek())
+	worklogs := []api.WorklogResponse{
+		{StartDate: "2026-10-05", TimeSpentSeconds: 8 * 3600},
+		{StartDate: "2026-10-06", TimeSpentSeconds: 6 * 3600},
+		{StartDate: "2026-10-07", TimeSpentSeconds: 10 * 3600},
+		{StartDate: "2026-10-08", TimeSpentSeconds: 8 * 3600},
+	}
+
+	tests := []struct {
+		name     string
+		until    time.Time
+		logged   int
+		required int
+		days     []string
+		short    bool
+	}{
+		{name: "whole week", until: monday.AddDate(0, 0, 6), logged: 32 * 3600, required: 40 * 3600, days: []string{"2026-10-06", "2026-10-07", "2026-10-09"}, short: true},
+		{name: "week in progress", until: monday.AddDate(0, 0, 2), logged: 24 * 3600, required: 24 * 3600, days: []string{"2026-10-06", "2026-10-07"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gap := compareWeek(monday, schedule, worklogs, tt.until)
+			if gap.logged != tt.logged || gap.required != tt.required || gap.short() != tt.short {
+				t.Errorf("compareWeek() = %d of %d (short %v), want %d of %d (short %v)", gap.logged, gap.required, gap.short(), tt.logged, tt.required, tt.short)
+			}
+			var days []string
+			for _, day := range gap.days {
+				days = append(days, day.day.Format(time.DateOnly))
+			}
+			if strings.Join(days, " ") != strings.Join(tt.days, " ") {
+				t.Errorf("compareWeek() days = %v, want %v", days, tt.days)
+			}
+		})
+	}
+}
+
+func TestExpectedSchedule(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	monday := time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC)
+	schedule := defaultSchedule(monday, calendar.DefaultWeek())
+	holidays := calendar.Holidays{"2026-12-25": "Christmas Day"}
+
+	if got := expectedSchedule(schedule, holidays).requiredTotal(); got != 32*3600 {
+		t.Errorf("expectedSchedule() without logAs requires %d, want 32 hours", got)
+	}
+	viper.Set(HOLIDAY_LOG_AS_CONFIG, ptoCategory)
+	if got := expectedSchedule(schedule, holidays).requiredTotal(); got != 40*3600 {
+		t.Errorf("expectedSchedule() with logAs requires %d, want 40 hours", got)
+	}
+}
+
+func TestPrintGaps(t *testing.T) {
+	monday := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	gaps := []weekGap{
+		{start: monday.AddDate(0, 0, -14), logged: 40 * 3600, required: 40 * 3600},
+		{start: monday.AddDate(0, 0, -7), logged: 40 * 3600, required: 40 * 3600, days: []dayGap{{day: monday.AddDate(0, 0, -7), logged: 10 * 3600, required: 8 * 3600}}},
+		{start: monday, logged: 32 * 3600, required: 40 * 3600, days: []dayGap{{day: monday.AddDate(0, 0, 4), required: 8 * 3600}}},
+	}
+
+	var out bytes.Buffer
+	printGaps(&out, gaps)
+	expected := "Week of 2026-09-28: 40 of 40 hours logged, but some days differ\n" +
+		"   Mon 2026-09-28  10 of 8 hours, 2 hours over\n" +
+		"Week of 2026-10-05: 32 of 40 hours logged, 8 hours short\n" +
+		"   Fri 2026-10-09  0 of 8 hours, 8 hours short\n"
+	if out.String() != expected {
+		t.Errorf("printGaps() =\n%s\nwant\n%s", out.String(), expected)
+	}
+}
+
+func TestDefaultRangeEnd(t *testing.T) {
+	week := calendar.DefaultWeek()
+	today := week.Now()
+	yesterday := today.AddDate(0, 0, -1).Format(time.DateOnly)
+
+	if got := defaultRangeEnd(today.AddDate(0, 0, -14), week).Format(time.DateOnly); got != yesterday {
+		t.Errorf("defaultRangeEnd() = %s, want yesterday %s so days still to come are not filled", got, yesterday)
+	}
+	if got := defaultRangeEnd(today, week); !got.Equal(today) {
+		t.Errorf("defaultRangeEnd() from today = %s, want today", got.Format(time.DateOnly))
+	}
+}
+
+func TestMissingRange(t *testing.T) {
+	week := calendar.DefaultWeek()
+	today := week.Now().Format(time.DateOnly)
+	lastWeek := week.Now().AddDate(0, 0, -7).Format(time.DateOnly)
+	yesterday := week.Now().AddDate(0, 0, -1).Format(time.DateOnly)
+
+	tests := []struct {
+		name      string
+		since     string
+		until     string
+		wantUntil string
+		wantErr   bool
+	}{
+		{name: "defaults to yesterday", since: lastWeek, wantUntil: yesterday},
+		{name: "since today checks today", since: today, wantUntil: today},
+		{name: "explicit until", since: lastWeek, until: today, wantUntil: today},
+		{name: "until before since", since: today, until: lastWeek, wantErr: true},
+		{name: "bad since", since: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, until, err := missingRange(tt.since, tt.until, week)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("missingRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && until.Format(time.DateOnly) != tt.wantUntil {
+				t.Errorf("missingRange() until = %s, want %s", until.Format(time.DateOnly), tt.wantUntil)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package timecard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// errAborted is returned when input ends, usually from Ctrl-D, before a question is answered.
+var errAborted = errors.New("aborted: no answer was given")
+
+// Prompter asks questions on a reader and writer. Answers are validated and the question is asked
+// again until one is valid, so a typo never ends an interactive session.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// errOut is where errors that end the run are written
+	errOut io.Writer
+	// interactive is false when the reader is not a terminal, so questions are better avoided than asked
+	interactive bool
+}
+
+// NewPrompter returns a Prompter that reads answers from in and writes questions to out, and errors to
+// stderr. It is
+// interactive unless in is a file, such as a redirected stdin or /dev/null, that is not a terminal.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	interactive := true
+	if file, ok := in.(*os.File); ok {
+		info, err := file.Stat()
+		interactive = err == nil && info.Mode()&os.ModeCharDevice != 0 && !isNullDevice(info)
+	}
+	return &Prompter{in: bufio.NewReader(in), out: out, errOut: os.Stderr, interactive: interactive}
+}
+
+// isNullDevice reports whether info describes os.DevNull, a character device that is still not a terminal.
+func isNullDevice(info os.FileInfo) bool {
+	null, err := os.Stat(os.DevNull)
+	return err == nil && os.SameFile(info, null)
+}
+
+// commandPrompter returns a Prompter over a command's input, output and error output, stdin, stdout and
+// stderr unless set.
+func commandPrompter(cmd *cobra.Command) *Prompter {
+	p := NewPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+	p.errOut = cmd.ErrOrStderr()
+	return p
+}
+
+// exitOnError ends the run when err is set, such as when a question could not be answered after Ctrl-D.
+func (p *Prompter) exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintln(p.errOut, err)
+		os.Exit(1)
+	}
+}
+
+// require fails fast, with hint on how to avoid the question, when the prompter cannot ask interactively.
+func (p *Prompter) require(question, hint string) error {
+	if p.interactive {
+		return nil
+	}
+	return fmt.Errorf("stdin is not a terminal, so timecard cannot ask %s; %s", question, hint)
+}
+
+// prompt formats question with defaultValue in brackets before its closing ":" or "?".
+func prompt(question, defaultValue string) string {
+	question = strings.TrimRight(question, " ")
+	end := ":"
+	if strings.HasSuffix(question, ":") || strings.HasSuffix(question, "?") {
+		end = question[len(question)-1:]
+		question = question[:len(question)-1]
+	}
+	if defaultValue != "" {
+		question += " [" + defaultValue + "]"
+	}
+	return question + end + " "
+}
+
+// readAnswer reads one line of input, trimmed. It returns errAborted when input has ended.
+func (p *Prompter) readAnswer() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		fmt.Fprintln(p.out)
+		return "", errAborted
+	}
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Ask asks question until validate accepts the answer, and returns it. A blank answer is defaultValue,
+// which is shown in brackets when set. A nil validate accepts anything, including a blank answer.
+func (p *Prompter) Ask(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		fmt.Fprint(p.out, prompt(question, defaultValue))
+		answer, err := p.readAnswer()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "%v. Please try again.\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// Confirm asks a yes or no question. y, yes, n and no are accepted in any case, and a blank answer is defaultYes.
+func (p *Prompter) Confirm(question string, defaultYes bool) (bool, error) {
+	choices := "y/N"
+	if defaultYes {
+		choices = "Y/n"
+	}
+	answer, err := p.Ask(question, choices, func(answer string) error {
+		if answer == choices {
+			return nil
+		}
+		_, err := parseYesNo(answer)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if answer == choices {
+		return defaultYes, nil
+	}
+	return parseYesNo(answer)
+}
+
+// parseYesNo reads a yes or no answer.
+func parseYesNo(answer string) (bool, error) {
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not yes or no", answer)
+}
+
+// Int asks for a whole number from min to max inclusive. A blank answer is defaultValue.
+func (p *Prompter) Int(question string, defaultValue, min, max int) (int, error) {
+	answer, err := p.Ask(question, strconv.Itoa(defaultValue), func(answer string) error {
+		value, err := strconv.Atoi(answer)
+		if err != nil || value < min || value > max {
+			return fmt.Errorf("%q is not a whole number from %d to %d", answer, min, max)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
+
+// Time asks for a non-negative amount of time, as hours ("7.5") or a duration ("7h30m", "1d"),
+// and returns it in seconds. A day is dayLength long.
+func (p *Prompter) Time(question string, dayLength time.Duration) (int, error) {
+	answer, err := p.Ask(question, "", func(answer string) error {
+		_, err := parseTimeInput(answer, dayLength)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return parseTimeInput(answer, dayLength)
+}
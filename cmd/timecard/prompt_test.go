@@ -0,0 +1,146 @@
+package timecard
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPrompterAsk(t *testing.T) {
+	var out bytes.Buffer
+	p := NewPrompter(strings.NewReader("\n  PROJ-7  \n"), &out)
+	answer, err := p.Ask("Default issue:", "", notEmpty("Issue"))
+	if err != nil || answer != "PROJ-7" {
+		t.Errorf("Ask() = %q, %v; want PROJ-7", answer, err)
+	}
+	if want := "Default issue: Issue cannot be empty. Please try again.\nDefault issue: "; out.String() != want {
+		t.Errorf("Ask() wrote %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	p = NewPrompter(strings.NewReader("\n"), &out)
+	answer, err = p.Ask("Which work type is used for pto time?", "20E", nil)
+	if err != nil || answer != "20E" {
+		t.Errorf("Ask() with default = %q, %v; want 20E", answer, err)
+	}
+	if want := "Which work type is used for pto time [20E]? "; out.String() != want {
+		t.Errorf("Ask() wrote %q, want %q", out.String(), want)
+	}
+
+	// The last answer counts even without a trailing newline
+	p = NewPrompter(strings.NewReader("last"), &out)
+	if answer, err := p.Ask("Week:", "", nil); err != nil || answer != "last" {
+		t.Errorf("Ask() without newline = %q, %v; want last", answer, err)
+	}
+	if _, err := p.Ask("Week:", "this", nil); !errors.Is(err, errAborted) {
+		t.Errorf("Ask() at end of input error = %v, want errAborted", err)
+	}
+}
+
+func TestPrompterConfirm(t *testing.T) {
+	tests := []struct {
+		input      string
+		defaultYes bool
+		expected   bool
+		wantErr    bool
+	}{
+		{input: "y\n", expected: true},
+		{input: "YES\n", expected: true},
+		{input: "No\n", defaultYes: true, expected: false},
+		{input: "\n", defaultYes: true, expected: true},
+		{input: "\n", expected: false},
+		{input: "sure\nyep\nn\n", defaultYes: true, expected: false},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.ReplaceAll(tt.input, "\n", "/"), func(t *testing.T) {
+			got, err := NewPrompter(strings.NewReader(tt.input), &bytes.Buffer{}).Confirm("Submit anyway?", tt.defaultYes)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("Confirm() = %v, %v; want %v", got, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrompterConfirmShowsDefault(t *testing.T) {
+	var out bytes.Buffer
+	NewPrompter(strings.NewReader("\n"), &out).Confirm("Submit anyway?", false)
+	if out.String() != "Submit anyway [y/N]? " {
+		t.Errorf("Confirm() wrote %q", out.String())
+	}
+}
+
+func TestPrompterInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{name: "number", input: "3\n", expected: 3},
+		{name: "default", input: "\n", expected: 1},
+		{name: "zero", input: "0\n", expected: 0},
+		{name: "re-asks until in range", input: "abc\n-2\n53\n52\n", expected: 52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPrompter(strings.NewReader(tt.input), &bytes.Buffer{}).Int("Weeks back:", 1, 0, 52)
+			if err != nil || got != tt.expected {
+				t.Errorf("Int() = %d, %v; want %d", got, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrompterTime(t *testing.T) {
+	var out bytes.Buffer
+	got, err := NewPrompter(strings.NewReader("-4\n7h30m\n"), &out).Time("Hours:", 8*time.Hour)
+	if err != nil || got != 27000 {
+		t.Errorf("Time() = %d, %v; want 27000", got, err)
+	}
+	if !strings.Contains(out.String(), "cannot be negative") {
+		t.Errorf("expected a negative time to be rejected, got %q", out.String())
+	}
+}
+
+func TestNewPrompterInteractive(t *testing.T) {
+	if p := NewPrompter(strings.NewReader(""), &bytes.Buffer{}); !p.interactive {
+		t.Error("a prompter over a plain reader should be interactive")
+	}
+
+	file, err := os.CreateTemp(t.TempDir(), "answers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	p := NewPrompter(file, &bytes.Buffer{})
+	if p.interactive {
+		t.Error("a prompter over a regular file should not be interactive")
+	}
+	if err := p.require("for the time", "pass --preset"); err == nil || !strings.Contains(err.Error(), "pass --preset") {
+		t.Errorf("require() error = %v, want a hint to pass --preset", err)
+	}
+}
+
+func TestCommandPrompterWritesErrorsToErrOut(t *testing.T) {
+	var out, errOut bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	if p := commandPrompter(cmd); p.out != &out || p.errOut != &errOut {
+		t.Error("commandPrompter() should write questions to the command's output and errors to its error output")
+	}
+}
@@ -0,0 +1,134 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+)
+
+// weekSchedule is when, and for how long, the user is expected to work during one week.
+type weekSchedule struct {
+	// days are the working days in order
+	days []time.Time
+	// required is the seconds expected on each working day, keyed by date
+	required map[string]int
+	// fromTempo is true when the schedule came from the user's Tempo schedule
+	fromTempo bool
+}
+
+// requiredTotal returns the seconds expected across the whole week.
+func (s weekSchedule) requiredTotal() int {
+	total := 0
+	for _, day := range s.days {
+		total += s.required[day.Format(time.DateOnly)]
+	}
+	return total
+}
+
+// requiredPerDay returns the seconds expected on each working day in order, for spreading time the way
+// the schedule does. It is nil unless the schedule came from Tempo, since the default schedule is uniform.
+func (s weekSchedule) requiredPerDay() []int {
+	if !s.fromTempo {
+		return nil
+	}
+	perDay := make([]int, len(s.days))
+	for i, day := range s.days {
+		perDay[i] = s.requiredOn(day)
+	}
+	return perDay
+}
+
+// requiredOn returns the seconds expected on day, or 0 if it is not a working day.
+func (s weekSchedule) requiredOn(day time.Time) int {
+	return s.required[day.Format(time.DateOnly)]
+}
+
+// withoutHolidays drops holidays from the working days.
+func (s weekSchedule) withoutHolidays(holidays calendar.Holidays) weekSchedule {
+	filtered := weekSchedule{days: []time.Time{}, required: map[string]int{}, fromTempo: s.fromTempo}
+	for _, day := range s.days {
+		if holidays.Contains(day) {
+			continue
+		}
+		filtered.days = append(filtered.days, day)
+		filtered.required[day.Format(time.DateOnly)] = s.requiredOn(day)
+	}
+	return filtered
+}
+
+// defaultSchedule assumes a full configured day on each working day of the week.
+func defaultSchedule(startOfWeek time.Time, week calendar.Week) weekSchedule {
+	schedule := weekSchedule{days: workingDays(startOfWeek, week, nil), required: map[string]int{}}
+	dayLength := int(configuredDayLength() / time.Second)
+	for _, day := range schedule.days {
+		schedule.required[day.Format(time.DateOnly)] = dayLength
+	}
+	return schedule
+}
+
+// onWorkingDays limits the schedule to the week's working days. Tempo's hours are kept as they are, so a day
+// Tempo requires nothing on, such as a part-time day off or a scheduled holiday, is not worked. Without a Tempo
+// schedule every working day is expected to be a full configured day.
+func (s weekSchedule) onWorkingDays(startOfWeek time.Time, week calendar.Week) weekSchedule {
+	dayLength := int(configuredDayLength() / time.Second)
+	adjusted := weekSchedule{days: []time.Time{}, required: map[string]int{}, fromTempo: s.fromTempo}
+	for _, day := range workingDays(startOfWeek, week, nil) {
+		required := s.requiredOn(day)
+		if !s.fromTempo && required == 0 {
+			required = dayLength
+		}
+		if required == 0 {
+			continue
+		}
+		adjusted.days = append(adjusted.days, day)
+		adjusted.required[day.Format(time.DateOnly)] = required
+	}
+	return adjusted
+}
+
+// scheduleFromTempo builds a week schedule from Tempo's per-day schedule.
+func scheduleFromTempo(startOfWeek time.Time, scheduleDays []api.ScheduleDay) weekSchedule {
+	byDate := make(map[string]api.ScheduleDay, len(scheduleDays))
+	for _, day := range scheduleDays {
+		byDate[day.Date] = day
+	}
+
+	schedule := weekSchedule{days: []time.Time{}, required: map[string]int{}, fromTempo: true}
+	for i := 0; i < daysPerWeek; i++ {
+		day := startOfWeek.AddDate(0, 0, i)
+		scheduleDay, ok := byDate[day.Format(time.DateOnly)]
+		if !ok || !scheduleDay.IsWorkingDay() {
+			continue
+		}
+		schedule.days = append(schedule.days, day)
+		schedule.required[scheduleDay.Date] = scheduleDay.RequiredSeconds
+	}
+	return schedule
+}
+
+// fetchSchedule loads the user's Tempo schedule for the week, falling back to the default schedule, with
+// a warning on out, when Tempo cannot provide one. Configured working days limit Tempo's.
+func fetchSchedule(out io.Writer, client *api.Client, accountId string, startOfWeek time.Time, week calendar.Week) weekSchedule {
+	scheduleDays, err := client.GetUserSchedule(accountId, startOfWeek, startOfWeek.AddDate(0, 0, daysPerWeek-1))
+	if err != nil {
+		fmt.Fprintf(out, "⚠️  Could not fetch your Tempo schedule, assuming %d days of %s: %v\n", len(week.WorkingDays), api.FormatHours(int(configuredDayLength()/time.Second)), err)
+		return defaultSchedule(startOfWeek, week)
+	}
+	schedule := scheduleFromTempo(startOfWeek, scheduleDays)
+	if workingDaysConfigured() {
+		schedule = schedule.onWorkingDays(startOfWeek, week)
+	}
+	return schedule
+}
+
+// plannedSeconds returns the total time of the planned worklogs.
+func plannedSeconds(planned []*api.WorklogRequest) int {
+	total := 0
+	for _, entry := range planned {
+		total += entry.TimeSpentSeconds
+	}
+	return total
+}
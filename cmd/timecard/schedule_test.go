@@ -0,0 +1,60 @@
+package timecard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+func TestScheduleFromTempo(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	days := []api.ScheduleDay{
+		{Date: "2026-10-12", RequiredSeconds: 8 * 3600, Type: "WORKING_DAY"},
+		{Date: "2026-10-13", RequiredSeconds: 8 * 3600, Type: "WORKING_DAY"},
+		{Date: "2026-10-14", RequiredSeconds: 4 * 3600, Type: "WORKING_DAY"},
+		{Date: "2026-10-15", RequiredSeconds: 0, Type: "HOLIDAY"},
+		{Date: "2026-10-16", RequiredSeconds: 8 * 3600, Type: "WORKING_DAY"},
+		{Date: "2026-10-17", RequiredSeconds: 0, Type: "NON_WORKING_DAY"},
+		{Date: "2026-10-18", RequiredSeconds: 0, Type: "NON_WORKING_DAY"},
+	}
+
+	schedule := scheduleFromTempo(monday, days)
+	if !schedule.fromTempo {
+		t.Error("scheduleFromTempo() should mark the schedule as coming from Tempo")
+	}
+	if len(schedule.days) != 4 {
+		t.Fatalf("scheduleFromTempo() days = %v, want 4 working days", schedule.days)
+	}
+	if schedule.requiredTotal() != 28*3600 {
+		t.Errorf("requiredTotal() = %d, want %d", schedule.requiredTotal(), 28*3600)
+	}
+	if got := schedule.requiredOn(monday.AddDate(0, 0, 2)); got != 4*3600 {
+		t.Errorf("requiredOn(Wednesday) = %d, want %d", got, 4*3600)
+	}
+}
+
+func TestDefaultScheduleWithoutHolidays(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	monday := time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC)
+	schedule := defaultSchedule(monday, calendar.DefaultWeek())
+	if len(schedule.days) != 5 || schedule.requiredTotal() != 40*3600 {
+		t.Fatalf("defaultSchedule() = %d days, %d seconds; want 5 days of 8 hours", len(schedule.days), schedule.requiredTotal())
+	}
+
+	filtered := schedule.withoutHolidays(map[string]string{"2026-12-25": "Christmas Day"})
+	if len(filtered.days) != 4 || filtered.requiredTotal() != 32*3600 {
+		t.Errorf("withoutHolidays() = %d days, %d seconds; want 4 days of 8 hours", len(filtered.days), filtered.requiredTotal())
+	}
+}
+
+func TestPlannedSeconds(t *testing.T) {
+	planned := []*api.WorklogRequest{{TimeSpentSeconds: 3600}, {TimeSpentSeconds: 1800}}
+	if got := plannedSeconds(planned); got != 5400 {
+		t.Errorf("plannedSeconds() = %d, want 5400", got)
+	}
+}
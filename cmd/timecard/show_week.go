@@ -0,0 +1,169 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/cobra"
+)
+
+// weekColumn is one work type column in the show-week table.
+type weekColumn struct {
+	label    string
+	workType api.WorkType
+}
+
+// unclassifiedLabel heads the column for worklogs that carry none of the known work types.
+const unclassifiedLabel = "Unclassified"
+
+// weekColumnsFor returns one column per configured time category.
+func weekColumnsFor(categories []timeCategory) []weekColumn {
+	columns := make([]weekColumn, len(categories))
+	for i, category := range categories {
+		columns[i] = weekColumn{label: category.Name, workType: category.workType()}
+	}
+	return columns
+}
+
+func ShowWeekCmd() *cobra.Command {
+	var week string
+	var weeksBack int
+
+	cmd := &cobra.Command{
+		Use:     "show-week",
+		Aliases: []string{"get-week"},
+		Short:   "Show the time logged in Tempo for a week",
+		Example: "timecard show-week --weeks-back 1",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prompter := commandPrompter(cmd)
+			accountId, _, err := fetchConfig(prompter)
+			if err != nil {
+				return err
+			}
+			calendarWeek, err := loadWeek()
+			if err != nil {
+				return err
+			}
+			startOfWeek, err := resolveWeek(week, weeksBack, calendarWeek.Now(), calendarWeek)
+			if err != nil {
+				return err
+			}
+
+			categories, err := loadCategories()
+			if err != nil {
+				return err
+			}
+			client := newTempoClient(fetchBearerToken(prompter))
+			worklogs, err := client.GetUserWorklogs(accountId, startOfWeek, startOfWeek.AddDate(0, 0, 6))
+			if err != nil {
+				return err
+			}
+
+			renderWeekTable(cmd.OutOrStdout(), startOfWeek, worklogs, weekColumnsFor(categories))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&week, "week", "", "Week to show: "+weekSelectorHelp)
+	cmd.Flags().IntVar(&weeksBack, "weeks-back", 0, "Number of weeks before the current week to show")
+	cmd.MarkFlagsMutuallyExclusive("week", "weeks-back")
+
+	return cmd
+}
+
+// resolveWeek returns the first day of the week selected by either --week or a number of weeks back.
+func resolveWeek(week string, weeksBack int, now time.Time, calendarWeek calendar.Week) (time.Time, error) {
+	if week != "" {
+		startOfWeek, err := selectWeek(week, now, calendarWeek)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("--week: %w", err)
+		}
+		return startOfWeek, nil
+	}
+	if weeksBack < 0 {
+		return time.Time{}, fmt.Errorf("--weeks-back cannot be negative")
+	}
+	return calendarWeek.StartOf(now).AddDate(0, 0, -daysPerWeek*weeksBack), nil
+}
+
+// renderWeekTable prints a day by work type table of hours with per-day and weekly totals.
+func renderWeekTable(out io.Writer, startOfWeek time.Time, worklogs []api.WorklogResponse, columns []weekColumn) {
+	// seconds[date][column] where column len(columns) collects unclassified worklogs
+	seconds := map[string][]int{}
+	unclassified := false
+	for _, worklog := range worklogs {
+		row, ok := seconds[worklog.StartDate]
+		if !ok {
+			row = make([]int, len(columns)+1)
+			seconds[worklog.StartDate] = row
+		}
+		col := len(columns)
+		for i, column := range columns {
+			if worklog.AttributeValue(column.workType.Key) == column.workType.Value {
+				col = i
+				break
+			}
+		}
+		if col == len(columns) {
+			unclassified = true
+		}
+		row[col] += worklog.TimeSpentSeconds
+	}
+
+	visible := len(columns)
+	if unclassified {
+		visible++
+	}
+
+	fmt.Fprintf(out, "Week of %s\n\n", startOfWeek.Format(time.DateOnly))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "Day\t")
+	for _, column := range columns {
+		fmt.Fprintf(w, "%s (%s)\t", column.label, column.workType.Value)
+	}
+	if unclassified {
+		fmt.Fprintf(w, "%s\t", unclassifiedLabel)
+	}
+	fmt.Fprint(w, "Total\t\n")
+
+	weekTotals := make([]int, len(columns)+1)
+	weekTotal := 0
+	for day := 0; day < daysPerWeek; day++ {
+		date := startOfWeek.AddDate(0, 0, day)
+		row := seconds[date.Format(time.DateOnly)]
+		if row == nil {
+			row = make([]int, len(columns)+1)
+		}
+
+		dayTotal := 0
+		fmt.Fprintf(w, "%s %s\t", date.Format("Mon"), date.Format(time.DateOnly))
+		for i := 0; i < visible; i++ {
+			fmt.Fprintf(w, "%s\t", formatTableHours(row[i]))
+			weekTotals[i] += row[i]
+			dayTotal += row[i]
+		}
+		weekTotal += dayTotal
+		fmt.Fprintf(w, "%s\t\n", formatTableHours(dayTotal))
+	}
+
+	fmt.Fprint(w, "Total\t")
+	for i := 0; i < visible; i++ {
+		fmt.Fprintf(w, "%s\t", formatTableHours(weekTotals[i]))
+	}
+	fmt.Fprintf(w, "%s\t\n", formatTableHours(weekTotal))
+	w.Flush()
+}
+
+// formatTableHours renders seconds as hours with at most two decimals, leaving empty cells as "-".
+func formatTableHours(seconds int) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(math.Round(float64(seconds)/36)/100, 'f', -1, 64)
+}
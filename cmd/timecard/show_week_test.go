@@ -0,0 +1,86 @@
+package timecard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+)
+
+func TestResolveWeek(t *testing.T) {
+	now := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		name      string
+		week      string
+		weeksBack int
+		expected  string
+		wantErr   bool
+	}{
+		{name: "current week", expected: "2024-01-08"},
+		{name: "two weeks back", weeksBack: 2, expected: "2023-12-25"},
+		{name: "date in week", week: "2024-01-14", expected: "2024-01-08"},
+		{name: "monday date", week: "2024-02-05", expected: "2024-02-05"},
+		{name: "invalid date", week: "next week", wantErr: true},
+		{name: "negative weeks back", weeksBack: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveWeek(tt.week, tt.weeksBack, now, calendar.DefaultWeek())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Format(time.DateOnly) != tt.expected {
+				t.Errorf("resolveWeek() = %s, want %s", got.Format(time.DateOnly), tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderWeekTable(t *testing.T) {
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	worklogs := []api.WorklogResponse{
+		existingWorklog(1, "2024-01-08", 6, 10001, "14C"),
+		existingWorklog(2, "2024-01-08", 2, 10001, "12E"),
+		existingWorklog(3, "2024-01-09", 8, 10001, "20E"),
+		{StartDate: "2024-01-10", TimeSpentSeconds: 1800},
+	}
+
+	var out bytes.Buffer
+	renderWeekTable(&out, monday, worklogs, weekColumnsFor(defaultCategories()))
+	table := out.String()
+
+	for _, want := range []string{"Week of 2024-01-08", "capitalizable (14C)", "pto (20E)", "other (12E)", "Unclassified"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table missing %q:\n%s", want, table)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	last := strings.Fields(lines[len(lines)-1])
+	if got := strings.Join(last, " "); got != "Total 6 8 2 0.5 16.5" {
+		t.Errorf("totals row = %q, want %q", got, "Total 6 8 2 0.5 16.5")
+	}
+	if !strings.Contains(table, "Mon 2024-01-08") || !strings.Contains(table, "Sun 2024-01-14") {
+		t.Errorf("table should list every day of the week:\n%s", table)
+	}
+}
+
+func TestFormatTableHours(t *testing.T) {
+	tests := map[int]string{0: "-", 3600: "1", 27000: "7.5", 900: "0.25", 144000: "40"}
+	for seconds, want := range tests {
+		if got := formatTableHours(seconds); got != want {
+			t.Errorf("formatTableHours(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package timecard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/danlafeir/devctl-timecard/api"
+)
+
+// submission tracks every worklog created during a single add-week run so a
+// failed run can be rolled back instead of leaving a half-submitted week behind.
+type submission struct {
+	client  *api.Client
+	created []api.WorklogResponse
+	// unknown are worklogs Tempo created without telling us their IDs, so they can only be deleted by hand
+	unknown []*api.WorklogRequest
+	// issues names issues by key in errors
+	issues *issueResolver
+	// prompter asks whether to roll back after a failure; without one nothing is rolled back unless atomic
+	prompter *Prompter
+	// out receives progress and the outcome of a rollback
+	out io.Writer
+}
+
+func newSubmission(client *api.Client, out io.Writer) *submission {
+	return &submission{client: client, out: out}
+}
+
+// send submits planned worklogs and records whatever Tempo created, even on failure.
+func (s *submission) send(planned []*api.WorklogRequest) error {
+	created, err := s.client.SubmitWorklogs(planned, s.out)
+	s.created = append(s.created, created...)
+	var unknown *api.UnknownWorklogError
+	if errors.As(err, &unknown) {
+		s.unknown = append(s.unknown, unknown.Request)
+	}
+	if err != nil && len(created) < len(planned) {
+		return fmt.Errorf("%s: %w", s.issues.key(planned[len(created)].IssueID), err)
+	}
+	return err
+}
+
+// rollback deletes every worklog created so far, continuing past individual failures. Worklogs whose
+// IDs are unknown cannot be deleted, so they are reported as needing to be deleted in Tempo by hand.
+func (s *submission) rollback() error {
+	var errs []error
+	for _, entry := range s.unknown {
+		errs = append(errs, fmt.Errorf("the worklog for %s on %s was created but its ID is unknown, delete it in Tempo by hand", entry.StartDate, s.issues.key(entry.IssueID)))
+	}
+	for i := len(s.created) - 1; i >= 0; i-- {
+		worklog := s.created[i]
+		if err := s.client.DeleteWorklog(worklog.TempoWorklogID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete worklog %d (%s): %w", worklog.TempoWorklogID, worklog.StartDate, err))
+			continue
+		}
+		fmt.Fprintf(s.out, "Deleted worklog %d for %s\n", worklog.TempoWorklogID, worklog.StartDate)
+	}
+	s.created = nil
+	s.unknown = nil
+	return errors.Join(errs...)
+}
+
+// handleFailure decides what to do with the worklogs created before sendErr.
+// With atomic set they are deleted straight away, otherwise the user is asked first.
+func (s *submission) handleFailure(sendErr error, atomic bool) error {
+	created := len(s.created) + len(s.unknown)
+	if created == 0 {
+		return sendErr
+	}
+
+	fmt.Fprintf(s.out, "❌ Submission failed after creating %d worklog(s): %v\n", created, sendErr)
+	if !atomic && !s.confirmRollback() {
+		return fmt.Errorf("%w (kept %d worklog(s) already created)", sendErr, created)
+	}
+
+	if err := s.rollback(); err != nil {
+		return fmt.Errorf("%w; rollback was incomplete: %v", sendErr, err)
+	}
+	fmt.Fprintln(s.out, "↩️  Rolled back all worklogs created in this run.")
+	return sendErr
+}
+
+// confirmRollback asks whether to delete the worklogs created so far.
+func (s *submission) confirmRollback() bool {
+	if s.prompter == nil || !s.prompter.interactive {
+		fmt.Fprintln(s.out, "Not asking about a rollback because stdin is not a terminal; pass --atomic or --yes to roll back automatically.")
+		return false
+	}
+	rollback, err := s.prompter.Confirm(fmt.Sprintf("Delete the %d worklog(s) created in this run?", len(s.created)+len(s.unknown)), false)
+	return err == nil && rollback
+}
@@ -0,0 +1,137 @@
+package timecard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+)
+
+// fakeTempo is a minimal Tempo stand-in that creates worklogs until failAfter is reached.
+type fakeTempo struct {
+	mu        sync.Mutex
+	nextID    int
+	failAfter int
+	// undecodable is the worklog whose creation is answered with a response that has no ID
+	undecodable int
+	live        map[int]bool
+}
+
+func (f *fakeTempo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case "POST":
+		if f.failAfter > 0 && f.nextID >= f.failAfter {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		f.live[f.nextID] = true
+		if f.nextID == f.undecodable {
+			w.Write([]byte("<html>created</html>"))
+			return
+		}
+		json.NewEncoder(w).Encode(api.WorklogResponse{TempoWorklogID: f.nextID})
+	case "DELETE":
+		var id int
+		parts := strings.Split(r.URL.Path, "/")
+		json.Unmarshal([]byte(parts[len(parts)-1]), &id)
+		delete(f.live, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// mustPlan plans a worklog and fails the test if planning fails.
+func mustPlan(t *testing.T, plan api.WorklogPlan) []*api.WorklogRequest {
+	t.Helper()
+	planned, err := api.PlanWorklog(plan)
+	if err != nil {
+		t.Fatalf("PlanWorklog() error = %v", err)
+	}
+	return planned
+}
+
+func newFakeTempo(failAfter int) (*fakeTempo, *api.Client, func()) {
+	fake := &fakeTempo{failAfter: failAfter, live: map[int]bool{}}
+	server := httptest.NewServer(fake)
+	return fake, api.NewClient("test-token", api.WithBaseURL(server.URL)), server.Close
+}
+
+func TestSubmission_AtomicRollback(t *testing.T) {
+	fake, client, closeServer := newFakeTempo(7)
+	defer closeServer()
+
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	sub := newSubmission(client, &out)
+	if err := sub.send(mustPlan(t, api.WorklogPlan{WorkType: api.CapitalizableWorkType, Seconds: 32 * 3600, StartDay: monday, AccountID: "acct", IssueID: "10001"})); err != nil {
+		t.Fatalf("first send failed: %v", err)
+	}
+	err := sub.send(mustPlan(t, api.WorklogPlan{WorkType: api.PtoWorkType, Seconds: 8 * 3600, StartDay: monday, AccountID: "acct", IssueID: "10001"}))
+	if err == nil {
+		t.Fatal("expected second send to fail")
+	}
+	if len(sub.created) != 7 {
+		t.Fatalf("tracked %d worklogs, want 7", len(sub.created))
+	}
+
+	if got := sub.handleFailure(err, true); !errors.Is(got, err) {
+		t.Errorf("handleFailure() = %v, want it to wrap %v", got, err)
+	}
+	if len(fake.live) != 0 {
+		t.Errorf("%d worklogs left behind after rollback", len(fake.live))
+	}
+	if len(sub.created) != 0 {
+		t.Errorf("created list should be cleared after rollback, got %d", len(sub.created))
+	}
+	for _, want := range []string{"Logging 6.5 hours for 2024-01-08", "Deleted worklog 7 for", "Rolled back all worklogs"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSubmission_HandleFailureWithNothingCreated(t *testing.T) {
+	_, client, closeServer := newFakeTempo(0)
+	defer closeServer()
+
+	sendErr := errors.New("boom")
+	sub := newSubmission(client, io.Discard)
+	if got := sub.handleFailure(sendErr, true); got != sendErr {
+		t.Errorf("handleFailure() = %v, want original error", got)
+	}
+}
+
+func TestSubmission_RollbackReportsWorklogsWithUnknownIDs(t *testing.T) {
+	fake, client, closeServer := newFakeTempo(0)
+	defer closeServer()
+	fake.undecodable = 3
+
+	monday := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	sub := newSubmission(client, io.Discard)
+	err := sub.send(mustPlan(t, api.WorklogPlan{WorkType: api.CapitalizableWorkType, Seconds: 40 * 3600, StartDay: monday, AccountID: "acct", IssueID: "10001"}))
+	if err == nil {
+		t.Fatal("expected send to fail when a worklog ID is unknown")
+	}
+	if len(sub.created) != 2 || len(sub.unknown) != 1 {
+		t.Fatalf("tracked %d created and %d unknown worklogs, want 2 and 1", len(sub.created), len(sub.unknown))
+	}
+
+	got := sub.handleFailure(err, true)
+	if !errors.Is(got, err) || !strings.Contains(got.Error(), "rollback was incomplete") || !strings.Contains(got.Error(), "2024-01-10") {
+		t.Errorf("handleFailure() = %v, want an incomplete rollback naming the 2024-01-10 worklog", got)
+	}
+	if len(fake.live) != 1 || !fake.live[3] {
+		t.Errorf("live worklogs = %v, want only the one with an unknown ID", fake.live)
+	}
+}
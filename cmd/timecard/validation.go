@@ -0,0 +1,250 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const VALIDATION_CONFIG = TOP_LEVEL_CONFIG + ".validation"
+
+const (
+	severityWarning = "warning"
+	severityError   = "error"
+)
+
+// violation is a validation rule a week's time breaks.
+type violation struct {
+	rule     string
+	severity string
+	message  string
+}
+
+// validationConfig is the validation section of the config file. Hours are given like answers to the time
+// questions ("40", "37h30m", "5d") and shares as percentages. Every rule is an error unless its severity is "warning".
+type validationConfig struct {
+	WeeklyHours struct {
+		Min      string `mapstructure:"min"`
+		Max      string `mapstructure:"max"`
+		Severity string `mapstructure:"severity"`
+	} `mapstructure:"weeklyHours"`
+	DailyHours struct {
+		Max      string `mapstructure:"max"`
+		Severity string `mapstructure:"severity"`
+	} `mapstructure:"dailyHours"`
+	PTO struct {
+		Category  string `mapstructure:"category"`
+		WholeDays bool   `mapstructure:"wholeDays"`
+		Severity  string `mapstructure:"severity"`
+	} `mapstructure:"pto"`
+	CapitalizableShare struct {
+		Category string  `mapstructure:"category"`
+		Min      float64 `mapstructure:"min"`
+		Max      float64 `mapstructure:"max"`
+		Severity string  `mapstructure:"severity"`
+	} `mapstructure:"capitalizableShare"`
+}
+
+// weekPolicy is the parsed validation config. Zero limits are not checked.
+type weekPolicy struct {
+	minWeekly, maxWeekly int
+	weeklySeverity       string
+	maxDaily             int
+	dailySeverity        string
+	// ptoCategory, when set, must be logged in whole days of dayLength
+	ptoCategory string
+	ptoSeverity string
+	dayLength   int
+	// shareCategory's share of the week's time must be from minShare to maxShare percent
+	shareCategory      string
+	minShare, maxShare float64
+	shareSeverity      string
+}
+
+// loadWeekPolicy reads the validation rules from config, checking them against the configured categories.
+func loadWeekPolicy(categories []timeCategory) (weekPolicy, error) {
+	var config validationConfig
+	if err := viper.UnmarshalKey(VALIDATION_CONFIG, &config); err != nil {
+		return weekPolicy{}, fmt.Errorf("invalid %s config: %w", VALIDATION_CONFIG, err)
+	}
+
+	dayLength := configuredDayLength()
+	policy := weekPolicy{dayLength: int(dayLength / time.Second)}
+	var err error
+	hours := func(key, value string) int {
+		if value == "" || err != nil {
+			return 0
+		}
+		var seconds int
+		if seconds, err = parseTimeInput(value, dayLength); err != nil {
+			err = fmt.Errorf("invalid %s.%s config: %w", VALIDATION_CONFIG, key, err)
+		}
+		return seconds
+	}
+	severity := func(key, value string) string {
+		switch strings.ToLower(value) {
+		case "", severityError:
+			return severityError
+		case severityWarning:
+			return severityWarning
+		}
+		if err == nil {
+			err = fmt.Errorf("invalid %s.%s.severity %q, expected %s or %s", VALIDATION_CONFIG, key, value, severityError, severityWarning)
+		}
+		return ""
+	}
+	category := func(key, name, fallback string) string {
+		if name == "" {
+			name = fallback
+		}
+		for _, category := range categories {
+			if category.Name == name {
+				return name
+			}
+		}
+		if err == nil {
+			err = fmt.Errorf("invalid %s.%s.category: %q is not a configured category", VALIDATION_CONFIG, key, name)
+		}
+		return ""
+	}
+
+	policy.minWeekly = hours("weeklyHours.min", config.WeeklyHours.Min)
+	policy.maxWeekly = hours("weeklyHours.max", config.WeeklyHours.Max)
+	policy.weeklySeverity = severity("weeklyHours", config.WeeklyHours.Severity)
+	policy.maxDaily = hours("dailyHours.max", config.DailyHours.Max)
+	policy.dailySeverity = severity("dailyHours", config.DailyHours.Severity)
+	if config.PTO.WholeDays {
+		policy.ptoCategory = category("pto", config.PTO.Category, ptoCategory)
+	}
+	policy.ptoSeverity = severity("pto", config.PTO.Severity)
+	share := config.CapitalizableShare
+	if share.Min != 0 || share.Max != 0 {
+		policy.shareCategory = category("capitalizableShare", share.Category, capitalizableCategory)
+		policy.minShare, policy.maxShare = share.Min, share.Max
+	}
+	policy.shareSeverity = severity("capitalizableShare", share.Severity)
+	if err != nil {
+		return weekPolicy{}, err
+	}
+
+	if policy.maxWeekly > 0 && policy.minWeekly > policy.maxWeekly {
+		return weekPolicy{}, fmt.Errorf("invalid %s config: weeklyHours.min is more than weeklyHours.max", VALIDATION_CONFIG)
+	}
+	if share.Min < 0 || share.Max > 100 || (share.Max > 0 && share.Min > share.Max) {
+		return weekPolicy{}, fmt.Errorf("invalid %s config: capitalizableShare must be percentages with min no more than max", VALIDATION_CONFIG)
+	}
+	return policy, nil
+}
+
+// weekTime is a week's time, totalled the ways the validation rules look at it.
+type weekTime struct {
+	total      int
+	byDay      map[string]int
+	byCategory map[string]int
+}
+
+// tallyWeek totals what is already logged plus what is planned. Worklogs are put in a category by work type.
+func tallyWeek(categories []timeCategory, planned []*api.WorklogRequest, existing []api.WorklogResponse) weekTime {
+	tally := weekTime{byDay: map[string]int{}, byCategory: map[string]int{}}
+	add := func(date, workType string, seconds int) {
+		tally.total += seconds
+		tally.byDay[date] += seconds
+		for _, category := range categories {
+			if category.WorkType == workType {
+				tally.byCategory[category.Name] += seconds
+				break
+			}
+		}
+	}
+	for _, worklog := range existing {
+		add(worklog.StartDate, worklog.AttributeValue(api.WorkTypeAttributeKey), worklog.TimeSpentSeconds)
+	}
+	for _, entry := range planned {
+		workType := ""
+		if len(entry.Attributes) > 0 {
+			workType = entry.Attributes[0].Value
+		}
+		add(entry.StartDate, workType, entry.TimeSpentSeconds)
+	}
+	return tally
+}
+
+// check returns every rule the week's time breaks, in a stable order.
+func (p weekPolicy) check(week weekTime) []violation {
+	var violations []violation
+	if p.minWeekly > 0 && week.total < p.minWeekly {
+		violations = append(violations, violation{rule: "weekly hours", severity: p.weeklySeverity,
+			message: fmt.Sprintf("the week has %s, less than the minimum of %s", api.FormatHours(week.total), api.FormatHours(p.minWeekly))})
+	}
+	if p.maxWeekly > 0 && week.total > p.maxWeekly {
+		violations = append(violations, violation{rule: "weekly hours", severity: p.weeklySeverity,
+			message: fmt.Sprintf("the week has %s, more than the maximum of %s", api.FormatHours(week.total), api.FormatHours(p.maxWeekly))})
+	}
+	if p.maxDaily > 0 {
+		days := make([]string, 0, len(week.byDay))
+		for day := range week.byDay {
+			days = append(days, day)
+		}
+		sort.Strings(days)
+		for _, day := range days {
+			if week.byDay[day] > p.maxDaily {
+				violations = append(violations, violation{rule: "daily hours", severity: p.dailySeverity,
+					message: fmt.Sprintf("%s has %s, more than the maximum of %s a day", day, api.FormatHours(week.byDay[day]), api.FormatHours(p.maxDaily))})
+			}
+		}
+	}
+	if p.ptoCategory != "" && p.dayLength > 0 && week.byCategory[p.ptoCategory]%p.dayLength != 0 {
+		violations = append(violations, violation{rule: "whole PTO days", severity: p.ptoSeverity,
+			message: fmt.Sprintf("%s of %s is not a whole number of %s days", api.FormatHours(week.byCategory[p.ptoCategory]), p.ptoCategory, api.FormatHours(p.dayLength))})
+	}
+	if p.shareCategory != "" && week.total > 0 {
+		share := float64(week.byCategory[p.shareCategory]) * 100 / float64(week.total)
+		if share < p.minShare || (p.maxShare > 0 && share > p.maxShare) {
+			violations = append(violations, violation{rule: "capitalizable share", severity: p.shareSeverity,
+				message: fmt.Sprintf("%s is %.0f%% of the week, outside the allowed %g%% to %g%%", p.shareCategory, share, p.minShare, shareLimit(p.maxShare))})
+		}
+	}
+	return violations
+}
+
+// shareLimit returns the upper share limit for display, where 0 means no limit.
+func shareLimit(max float64) float64 {
+	if max == 0 {
+		return 100
+	}
+	return max
+}
+
+// enforceViolations prints every violation and fails on errors, unless overrideReason says why the week
+// should be submitted anyway. It reports whether errors were overridden, so the reason can be recorded.
+func enforceViolations(out io.Writer, violations []violation, overrideReason string) (bool, error) {
+	failed := 0
+	for _, v := range violations {
+		if v.severity == severityError {
+			failed++
+			fmt.Fprintf(out, "❌ %s: %s\n", v.rule, v.message)
+		} else {
+			fmt.Fprintf(out, "⚠️  %s: %s\n", v.rule, v.message)
+		}
+	}
+	if failed == 0 {
+		return false, nil
+	}
+	if strings.TrimSpace(overrideReason) == "" {
+		return false, fmt.Errorf("%d validation rule(s) failed; correct the time or pass --override-reason to submit anyway", failed)
+	}
+	fmt.Fprintf(out, "📝 Submitting anyway: %s\n", overrideReason)
+	return true, nil
+}
+
+// recordOverride notes the override reason in the description of every planned worklog so it is kept in Tempo.
+func recordOverride(planned []*api.WorklogRequest, overrideReason string) {
+	for _, entry := range planned {
+		entry.Description = strings.TrimSpace(fmt.Sprintf("%s [override: %s]", entry.Description, strings.TrimSpace(overrideReason)))
+	}
+}
@@ -0,0 +1,151 @@
+package timecard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+func TestLoadWeekPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{name: "no rules", config: map[string]any{}},
+		{name: "every rule", config: map[string]any{
+			"weeklyHours":        map[string]any{"min": "40", "max": "45", "severity": "warning"},
+			"dailyHours":         map[string]any{"max": "10h"},
+			"pto":                map[string]any{"wholeDays": true},
+			"capitalizableShare": map[string]any{"min": 50, "max": 90},
+		}},
+		{name: "bad hours", config: map[string]any{"dailyHours": map[string]any{"max": "lots"}}, wantErr: "dailyHours.max"},
+		{name: "bad severity", config: map[string]any{"weeklyHours": map[string]any{"max": "45", "severity": "fatal"}}, wantErr: "weeklyHours.severity"},
+		{name: "min over max", config: map[string]any{"weeklyHours": map[string]any{"min": "50", "max": "45"}}, wantErr: "min is more than"},
+		{name: "unknown category", config: map[string]any{"pto": map[string]any{"wholeDays": true, "category": "leave"}}, wantErr: "not a configured category"},
+		{name: "share over 100", config: map[string]any{"capitalizableShare": map[string]any{"max": 120}}, wantErr: "percentages"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			viper.Set(VALIDATION_CONFIG, tt.config)
+
+			_, err := loadWeekPolicy(defaultCategories())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadWeekPolicy() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadWeekPolicy() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWeekPolicyCheck(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set(VALIDATION_CONFIG, map[string]any{
+		"weeklyHours":        map[string]any{"min": "40", "max": "45", "severity": "warning"},
+		"dailyHours":         map[string]any{"max": "10"},
+		"pto":                map[string]any{"wholeDays": true},
+		"capitalizableShare": map[string]any{"min": 50},
+	})
+	categories := defaultCategories()
+	policy, err := loadWeekPolicy(categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := func(date string, workType api.WorkType, hours int) *api.WorklogRequest {
+		return &api.WorklogRequest{StartDate: date, TimeSpentSeconds: hours * 3600, Attributes: []api.WorkType{workType}}
+	}
+	tests := []struct {
+		name     string
+		planned  []*api.WorklogRequest
+		existing []api.WorklogResponse
+		expected []string
+	}{
+		{
+			name: "valid week",
+			planned: []*api.WorklogRequest{
+				entry("2026-10-05", api.CapitalizableWorkType, 8), entry("2026-10-06", api.CapitalizableWorkType, 8),
+				entry("2026-10-07", api.CapitalizableWorkType, 8), entry("2026-10-08", api.OtherWorkType, 8),
+				entry("2026-10-09", api.PtoWorkType, 8),
+			},
+		},
+		{
+			name:     "short week",
+			planned:  []*api.WorklogRequest{entry("2026-10-05", api.CapitalizableWorkType, 8)},
+			expected: []string{"warning weekly hours"},
+		},
+		{
+			name: "long day with existing time",
+			planned: []*api.WorklogRequest{
+				entry("2026-10-05", api.CapitalizableWorkType, 8), entry("2026-10-06", api.CapitalizableWorkType, 8),
+				entry("2026-10-07", api.CapitalizableWorkType, 8), entry("2026-10-08", api.CapitalizableWorkType, 8),
+				entry("2026-10-09", api.CapitalizableWorkType, 8),
+			},
+			existing: []api.WorklogResponse{{StartDate: "2026-10-05", TimeSpentSeconds: 4 * 3600}},
+			expected: []string{"error daily hours"},
+		},
+		{
+			name: "half a PTO day and too little capitalizable time",
+			planned: []*api.WorklogRequest{
+				entry("2026-10-05", api.OtherWorkType, 8), entry("2026-10-06", api.OtherWorkType, 8),
+				entry("2026-10-07", api.OtherWorkType, 8), entry("2026-10-08", api.CapitalizableWorkType, 8),
+				entry("2026-10-09", api.OtherWorkType, 4), entry("2026-10-09", api.PtoWorkType, 4),
+			},
+			expected: []string{"error whole PTO days", "error capitalizable share"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range policy.check(tallyWeek(categories, tt.planned, tt.existing)) {
+				got = append(got, v.severity+" "+v.rule)
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.expected, ", ") {
+				t.Errorf("check() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnforceViolations(t *testing.T) {
+	violations := []violation{
+		{rule: "weekly hours", severity: severityWarning, message: "the week has 38 hours"},
+		{rule: "daily hours", severity: severityError, message: "2026-10-05 has 12 hours"},
+	}
+
+	var out bytes.Buffer
+	if _, err := enforceViolations(&out, violations[:1], ""); err != nil {
+		t.Errorf("enforceViolations() with only warnings error = %v", err)
+	}
+	if _, err := enforceViolations(&out, violations, ""); err == nil || !strings.Contains(err.Error(), "--override-reason") {
+		t.Errorf("enforceViolations() error = %v, want a hint to pass --override-reason", err)
+	}
+	overridden, err := enforceViolations(&out, violations, "on call")
+	if err != nil || !overridden {
+		t.Errorf("enforceViolations() with a reason = %v, %v; want overridden", overridden, err)
+	}
+	for _, want := range []string{"⚠️  weekly hours: the week has 38 hours", "❌ daily hours: 2026-10-05 has 12 hours", "📝 Submitting anyway: on call"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	planned := []*api.WorklogRequest{{Description: "capitalizable time for the week of 2026-10-05"}}
+	recordOverride(planned, " on call ")
+	if planned[0].Description != "capitalizable time for the week of 2026-10-05 [override: on call]" {
+		t.Errorf("recordOverride() description = %q", planned[0].Description)
+	}
+}
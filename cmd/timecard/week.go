@@ -0,0 +1,70 @@
+package timecard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+const WEEK_CONFIG = TOP_LEVEL_CONFIG + ".week"
+const WEEK_START_CONFIG = WEEK_CONFIG + ".start"
+const WORKING_DAYS_CONFIG = WEEK_CONFIG + ".workingDays"
+const TIME_ZONE_CONFIG = TOP_LEVEL_CONFIG + ".timeZone"
+
+// loadWeek reads the configured week start, working weekdays and time zone, defaulting to a
+// Monday to Friday week in the machine's local zone.
+func loadWeek() (calendar.Week, error) {
+	week, err := calendar.NewWeek(viper.GetString(WEEK_START_CONFIG), viper.GetStringSlice(WORKING_DAYS_CONFIG))
+	if err != nil {
+		return calendar.Week{}, fmt.Errorf("invalid %s config: %w", WEEK_CONFIG, err)
+	}
+	week.Location, err = calendar.LoadLocation(viper.GetString(TIME_ZONE_CONFIG))
+	if err != nil {
+		return calendar.Week{}, fmt.Errorf("invalid %s config: %w", TIME_ZONE_CONFIG, err)
+	}
+	return week, nil
+}
+
+// workingDaysConfigured reports whether the user picked their own working weekdays, which then take
+// precedence over the working days in their Tempo schedule.
+func workingDaysConfigured() bool {
+	return viper.IsSet(WORKING_DAYS_CONFIG)
+}
+
+// weekSelectorHelp describes the values selectWeek accepts, for flag help and errors.
+const weekSelectorHelp = "this, last, a number of weeks back (-3), an ISO week (2026-W41) or a date (YYYY-MM-DD)"
+
+// selectWeek returns the first day of the week picked by selector, relative to now: "this", "last",
+// a negative number of weeks back, an ISO week or any date within the week.
+func selectWeek(selector string, now time.Time, week calendar.Week) (time.Time, error) {
+	selector = strings.TrimSpace(selector)
+	switch strings.ToLower(selector) {
+	case "this", "0":
+		return week.StartOf(now), nil
+	case "last":
+		return week.StartOf(now).AddDate(0, 0, -daysPerWeek), nil
+	}
+	if strings.HasPrefix(selector, "-") {
+		weeksBack, err := strconv.Atoi(selector[1:])
+		if err != nil || weeksBack < 0 {
+			return time.Time{}, fmt.Errorf("invalid week %q, expected %s", selector, weekSelectorHelp)
+		}
+		return week.StartOf(now).AddDate(0, 0, -daysPerWeek*weeksBack), nil
+	}
+	if strings.Contains(strings.ToUpper(selector), "-W") {
+		monday, err := week.ParseISOWeek(strings.ToUpper(selector))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return week.StartOf(monday), nil
+	}
+	date, err := week.ParseDate(selector)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid week %q, expected %s", selector, weekSelectorHelp)
+	}
+	return week.StartOf(date), nil
+}
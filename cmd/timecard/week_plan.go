@@ -0,0 +1,92 @@
+package timecard
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+)
+
+// weekPlanner turns the time given for a week into worklogs, the same way for add-week and backfill.
+type weekPlanner struct {
+	accountId  string
+	issueId    string
+	categories []timeCategory
+	// allocations are --alloc specs keyed by category name, winning over configured allocations
+	allocations map[string]string
+	// distribute, when set, overrides every category's distribution strategy
+	distribute string
+	holidays   calendar.Holidays
+	week       calendar.Week
+	// describer holds the description templates; the week and notes are filled in by plan
+	describer describer
+	// out is told about holidays and issue splits as the week is planned
+	out io.Writer
+}
+
+// plan returns the worklogs for the week starting at startOfWeek: a day for each holiday, then each
+// category's seconds spread over the schedule's working days and split across the category's issues.
+func (p weekPlanner) plan(startOfWeek time.Time, fullSchedule weekSchedule, seconds map[string]int, notes map[string]string) ([]*api.WorklogRequest, error) {
+	describer := p.describer
+	describer.startOfWeek = startOfWeek
+	describer.notes = notes
+
+	weekHolidays := holidaysInWeek(startOfWeek, p.week, p.holidays)
+	planned, err := planHolidays(p.out, p.categories, weekHolidays, p.holidays, fullSchedule, describer, p.accountId, p.issueId)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := fullSchedule.withoutHolidays(p.holidays)
+	// Categories share what each day still needs, so together they add up to the schedule
+	required := schedule.requiredPerDay()
+	for _, category := range p.categories {
+		distributor, err := category.distributor(p.distribute, required)
+		if err != nil {
+			return nil, err
+		}
+		issues, split, err := issueShares(p.out, category, p.allocations[category.Name], p.issueId, seconds[category.Name], describer.issues)
+		if err != nil {
+			return nil, err
+		}
+		for i, issue := range issues {
+			entries, err := api.PlanWorklog(api.WorklogPlan{
+				WorkType:    category.workType(),
+				Seconds:     split[i],
+				StartDay:    startOfWeek,
+				AccountID:   p.accountId,
+				IssueID:     issue,
+				Days:        schedule.days,
+				Distributor: distributor,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("cannot spread %s time: %w", category.Name, err)
+			}
+			if err := describer.describe(entries, category); err != nil {
+				return nil, err
+			}
+			planned = append(planned, entries...)
+		}
+	}
+	return planned, nil
+}
+
+// resolveIssues turns the default issue and every category's own issue into numeric IDs, looking keys up
+// in Jira. It returns the default issue's ID and updates categories in place.
+func resolveIssues(resolver *issueResolver, issueId string, categories []timeCategory) (string, error) {
+	issueId, err := resolver.id(issueId)
+	if err != nil {
+		return "", fmt.Errorf("default issue: %w", err)
+	}
+	for i, category := range categories {
+		if category.IssueID == "" {
+			continue
+		}
+		if categories[i].IssueID, err = resolver.id(category.IssueID); err != nil {
+			return "", fmt.Errorf("category %q: %w", category.Name, err)
+		}
+	}
+	return issueId, nil
+}
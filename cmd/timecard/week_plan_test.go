@@ -0,0 +1,46 @@
+package timecard
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+func TestWeekPlannerFillsEachScheduledDay(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	monday := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	week := calendar.DefaultWeek()
+	schedule := weekSchedule{days: week.WorkingDaysFrom(monday), required: map[string]int{}, fromTempo: true}
+	for _, day := range schedule.days {
+		schedule.required[day.Format(time.DateOnly)] = 8 * 3600
+	}
+
+	planner := weekPlanner{accountId: "acc", issueId: "10001", categories: defaultCategories(), week: week, out: io.Discard}
+	seconds := map[string]int{capitalizableCategory: 32 * 3600, ptoCategory: 8 * 3600, otherCategory: 0}
+	planned, err := planner.plan(monday, schedule, seconds, nil)
+	if err != nil {
+		t.Fatalf("plan() error = %v", err)
+	}
+
+	perDay := map[string]int{}
+	ptoDays := 0
+	for _, entry := range planned {
+		perDay[entry.StartDate] += entry.TimeSpentSeconds
+		if entry.Attributes[0].Value == workTypeFor(ptoCategory).Value {
+			ptoDays++
+		}
+	}
+	for _, day := range schedule.days {
+		if got := perDay[day.Format(time.DateOnly)]; got != schedule.requiredOn(day) {
+			t.Errorf("%s has %d seconds, want the scheduled %d", day.Format(time.DateOnly), got, schedule.requiredOn(day))
+		}
+	}
+	if ptoDays != 1 {
+		t.Errorf("PTO spread over %d days, want 1", ptoDays)
+	}
+}
@@ -0,0 +1,151 @@
+package timecard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danlafeir/devctl-timecard/pkg/calendar"
+	"github.com/spf13/viper"
+)
+
+func TestLoadWeek(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	week, err := loadWeek()
+	if err != nil || week.Start != time.Monday || len(week.WorkingDays) != 5 {
+		t.Fatalf("loadWeek() without config = %+v, %v; want Monday to Friday", week, err)
+	}
+
+	viper.Set(WEEK_START_CONFIG, "sunday")
+	viper.Set(WORKING_DAYS_CONFIG, []string{"sunday-thursday"})
+	week, err = loadWeek()
+	if err != nil {
+		t.Fatalf("loadWeek() error = %v", err)
+	}
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) // Friday
+	startOfWeek, err := resolveWeek("", 0, now, week)
+	if err != nil || startOfWeek.Format(time.DateOnly) != "2026-10-11" {
+		t.Errorf("resolveWeek() = %s, %v; want Sunday 2026-10-11", startOfWeek.Format(time.DateOnly), err)
+	}
+
+	days := workingDays(startOfWeek, week, nil)
+	if len(days) != 5 || days[0].Weekday() != time.Sunday || days[4].Weekday() != time.Thursday {
+		t.Errorf("workingDays() = %v, want Sunday to Thursday", days)
+	}
+
+	viper.Set(WORKING_DAYS_CONFIG, []string{"someday"})
+	if _, err := loadWeek(); err == nil {
+		t.Error("expected error for an unknown working day")
+	}
+}
+
+func TestLoadWeekTimeZone(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	week, err := loadWeek()
+	if err != nil || week.Location != time.Local {
+		t.Fatalf("loadWeek() without a zone = %v, %v; want Local", week.Location, err)
+	}
+
+	viper.Set(TIME_ZONE_CONFIG, "Asia/Tokyo")
+	week, err = loadWeek()
+	if err != nil || week.ZoneName() != "Asia/Tokyo" {
+		t.Fatalf("loadWeek() zone = %v, %v; want Asia/Tokyo", week.Location, err)
+	}
+
+	// late Sunday in UTC is already Monday in Tokyo
+	sundayNightUTC := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC)
+	startOfWeek, err := resolveWeek("", 0, sundayNightUTC, week)
+	if err != nil || startOfWeek.Format(time.DateOnly) != "2026-10-19" {
+		t.Errorf("resolveWeek() = %s, %v; want 2026-10-19", startOfWeek.Format(time.DateOnly), err)
+	}
+
+	viper.Set(TIME_ZONE_CONFIG, "America/Los_Angeles")
+	week, err = loadWeek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startOfWeek, err = resolveWeek("2026-10-19", 0, sundayNightUTC, week)
+	if err != nil || startOfWeek.Format(time.DateOnly) != "2026-10-19" {
+		t.Errorf("resolveWeek(2026-10-19) = %s, %v; want the Monday itself", startOfWeek.Format(time.DateOnly), err)
+	}
+
+	viper.Set(TIME_ZONE_CONFIG, "Nowhere/Special")
+	if _, err := loadWeek(); err == nil {
+		t.Error("expected error for an unknown time zone")
+	}
+}
+
+func TestScheduleOnWorkingDays(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set(WEEK_START_CONFIG, "tuesday")
+	viper.Set(WORKING_DAYS_CONFIG, []string{"tue-sat"})
+	week, err := loadWeek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tuesday := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+	required := map[string]int{"2026-10-13": 6 * 3600, "2026-10-14": 8 * 3600, "2026-10-17": 0, "2026-10-19": 8 * 3600}
+
+	schedule := weekSchedule{required: required, fromTempo: true}.onWorkingDays(tuesday, week)
+	if len(schedule.days) != 2 || schedule.days[1].Weekday() != time.Wednesday {
+		t.Fatalf("onWorkingDays() days = %v, want only the working days Tempo requires time on", schedule.days)
+	}
+	if schedule.requiredTotal() != (6+8)*3600 {
+		t.Errorf("requiredTotal() = %d, want Tempo's 6 hours on Tuesday and 8 on Wednesday", schedule.requiredTotal())
+	}
+
+	schedule = weekSchedule{required: required}.onWorkingDays(tuesday, week)
+	if len(schedule.days) != 5 || schedule.days[4].Weekday() != time.Saturday {
+		t.Fatalf("onWorkingDays() days = %v, want Tuesday to Saturday", schedule.days)
+	}
+	if schedule.requiredTotal() != (6+4*8)*3600 {
+		t.Errorf("requiredTotal() = %d, want 6 hours on Tuesday and a full day otherwise", schedule.requiredTotal())
+	}
+}
+
+func TestSelectWeek(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC) // Wednesday
+	week := calendar.DefaultWeek()
+	week.Location = time.UTC
+
+	tests := []struct {
+		selector string
+		expected string
+		wantErr  bool
+	}{
+		{selector: "this", expected: "2026-10-12"},
+		{selector: "THIS", expected: "2026-10-12"},
+		{selector: "last", expected: "2026-10-05"},
+		{selector: "-3", expected: "2026-09-21"},
+		{selector: "2026-W41", expected: "2026-10-05"},
+		{selector: "2026-w01", expected: "2025-12-29"},
+		{selector: "2026-10-18", expected: "2026-10-12"},
+		{selector: "-x", wantErr: true},
+		{selector: "2026-W60", wantErr: true},
+		{selector: "next", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := selectWeek(tt.selector, now, week)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("selectWeek() = %s, want error", got.Format(time.DateOnly))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectWeek() error = %v", err)
+			}
+			if got.Format(time.DateOnly) != tt.expected {
+				t.Errorf("selectWeek() = %s, want %s", got.Format(time.DateOnly), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package timecard
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+const WORK_TYPES_CONFIG = TOP_LEVEL_CONFIG + ".workTypes"
+
+// Time categories that map onto a value of Tempo's _WorkType_ attribute.
+const (
+	capitalizableCategory = "capitalizable"
+	ptoCategory           = "pto"
+	otherCategory         = "other"
+)
+
+// fallbackWorkTypes are the built-in work types used when nothing is configured.
+var fallbackWorkTypes = map[string]api.WorkType{
+	capitalizableCategory: api.CapitalizableWorkType,
+	ptoCategory:           api.PtoWorkType,
+	otherCategory:         api.OtherWorkType,
+}
+
+// workTypeFor returns the configured _WorkType_ value for a built-in category, or its default.
+func workTypeFor(category string) api.WorkType {
+	if value := viper.GetString(WORK_TYPES_CONFIG + "." + category); value != "" {
+		return api.WorkType{Key: api.WorkTypeAttributeKey, Value: value}
+	}
+	if workType, ok := fallbackWorkTypes[category]; ok {
+		return workType
+	}
+	return api.WorkType{Key: api.WorkTypeAttributeKey}
+}
+
+// configureWorkTypes lists the _WorkType_ values defined in Tempo and maps each time category onto one.
+// Without a terminal nothing is asked and the current mapping is kept.
+func configureWorkTypes(p *Prompter, client *api.Client, categories []timeCategory) {
+	if !p.interactive {
+		fmt.Fprintln(p.out, "stdin is not a terminal, keeping the current work type mapping.")
+		return
+	}
+	fmt.Fprint(p.out, "Fetching work types from Tempo API...\n")
+	attribute, err := client.GetWorkAttribute(api.WorkTypeAttributeKey)
+	if err != nil {
+		fmt.Fprintf(p.out, "Failed to fetch work types, keeping the current mapping: %v\n", err)
+		return
+	}
+	if attribute.Type != api.StaticListAttributeType || len(attribute.Values) == 0 {
+		fmt.Fprintf(p.out, "Work attribute %s is not a static list, keeping the current mapping.\n", attribute.Key)
+		return
+	}
+
+	fmt.Fprintf(p.out, "Available %s values:\n", attribute.Name)
+	for i, value := range attribute.Values {
+		fmt.Fprintf(p.out, "  %d) %s - %s\n", i+1, value, attribute.ValueName(value))
+	}
+
+	for i := range categories {
+		answer, err := p.Ask(fmt.Sprintf("Which work type is used for %s time?", categories[i].Name), categories[i].WorkType, func(answer string) error {
+			if _, ok := resolveWorkTypeChoice(answer, "", attribute); !ok {
+				return fmt.Errorf("enter a number from the list or one of the listed values")
+			}
+			return nil
+		})
+		p.exitOnError(err)
+		categories[i].WorkType, _ = resolveWorkTypeChoice(answer, "", attribute)
+	}
+	saveCategories(categories)
+}
+
+// resolveWorkTypeChoice turns a menu answer (a list number, a value, or empty for the default) into a work type value.
+func resolveWorkTypeChoice(answer, current string, attribute *api.WorkAttribute) (string, bool) {
+	if answer == "" {
+		return current, current != ""
+	}
+	if index, err := strconv.Atoi(answer); err == nil {
+		if index < 1 || index > len(attribute.Values) {
+			return "", false
+		}
+		return attribute.Values[index-1], true
+	}
+	if attribute.HasValue(answer) {
+		return answer, true
+	}
+	return "", false
+}
@@ -0,0 +1,54 @@
+package timecard
+
+import (
+	"testing"
+
+	"github.com/danlafeir/devctl-timecard/api"
+	"github.com/spf13/viper"
+)
+
+func TestWorkTypeFor(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	if got := workTypeFor(ptoCategory); got != api.PtoWorkType {
+		t.Errorf("workTypeFor(pto) without config = %v, want %v", got, api.PtoWorkType)
+	}
+
+	viper.Set(WORK_TYPES_CONFIG+"."+ptoCategory, "30V")
+	want := api.WorkType{Key: api.WorkTypeAttributeKey, Value: "30V"}
+	if got := workTypeFor(ptoCategory); got != want {
+		t.Errorf("workTypeFor(pto) with config = %v, want %v", got, want)
+	}
+	if got := workTypeFor(capitalizableCategory); got != api.CapitalizableWorkType {
+		t.Errorf("workTypeFor(capitalizable) = %v, want unchanged default", got)
+	}
+}
+
+func TestResolveWorkTypeChoice(t *testing.T) {
+	attribute := &api.WorkAttribute{Values: []string{"14C", "20E", "12E"}}
+
+	tests := []struct {
+		name     string
+		answer   string
+		current  string
+		expected string
+		ok       bool
+	}{
+		{name: "empty keeps current", answer: "", current: "14C", expected: "14C", ok: true},
+		{name: "empty without current", answer: "", current: "", ok: false},
+		{name: "list number", answer: "2", current: "14C", expected: "20E", ok: true},
+		{name: "number out of range", answer: "4", current: "14C", ok: false},
+		{name: "value", answer: "12E", current: "14C", expected: "12E", ok: true},
+		{name: "unknown value", answer: "99X", current: "14C", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveWorkTypeChoice(tt.answer, tt.current, attribute)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("resolveWorkTypeChoice(%q) = (%q, %v), want (%q, %v)", tt.answer, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
-import "github.com/danlafeir/devctl-timecard/cmd"
+import (
+	// Embed the IANA time zone database so configured zones work on machines without one
+	_ "time/tzdata"
+
+	"github.com/danlafeir/devctl-timecard/cmd"
+)
 
 // BuildGitHash is set at build time via -ldflags
 var BuildGitHash = "dev"
@@ -0,0 +1,155 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Holidays maps a date (YYYY-MM-DD) to the name of the holiday on it.
+type Holidays map[string]string
+
+// Contains reports whether day is a holiday.
+func (h Holidays) Contains(day time.Time) bool {
+	_, ok := h[day.Format(time.DateOnly)]
+	return ok
+}
+
+// Name returns the name of the holiday on day, or "" if there is none.
+func (h Holidays) Name(day time.Time) string {
+	return h[day.Format(time.DateOnly)]
+}
+
+// Add records a holiday, keeping the first name seen for a date.
+func (h Holidays) Add(day time.Time, name string) {
+	key := day.Format(time.DateOnly)
+	if _, ok := h[key]; !ok {
+		h[key] = name
+	}
+}
+
+// ParseDateList reads holidays from entries of the form "2026-12-25" or "2026-12-25 Christmas Day".
+func ParseDateList(entries []string) (Holidays, error) {
+	holidays := Holidays{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		date, name, _ := strings.Cut(entry, " ")
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q, expected YYYY-MM-DD optionally followed by a name", entry)
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = "Holiday"
+		}
+		holidays.Add(day, name)
+	}
+	return holidays, nil
+}
+
+// LoadICS reads all-day events from an iCalendar file as holidays.
+func LoadICS(path string) (Holidays, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open holiday calendar: %w", err)
+	}
+	defer file.Close()
+	return ParseICS(file)
+}
+
+// ParseICS reads VEVENTs from iCalendar data. Each day from DTSTART up to, but not including,
+// DTEND is a holiday named after the event's SUMMARY. Recurrence rules are not expanded.
+func ParseICS(r io.Reader) (Holidays, error) {
+	holidays := Holidays{}
+
+	var inEvent bool
+	var start, end, summary string
+	for _, line := range unfoldICSLines(r) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as DTSTART;VALUE=DATE
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, summary = true, "", "", ""
+			}
+		case "DTSTART":
+			start = value
+		case "DTEND":
+			end = value
+		case "SUMMARY":
+			summary = strings.ReplaceAll(value, `\,`, ",")
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if err := addICSEvent(holidays, start, end, summary); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return holidays, nil
+}
+
+func addICSEvent(holidays Holidays, start, end, summary string) error {
+	first, err := parseICSDate(start)
+	if err != nil {
+		return err
+	}
+	last := first
+	if end != "" {
+		endDay, err := parseICSDate(end)
+		if err != nil {
+			return err
+		}
+		// DTEND is exclusive for all-day events
+		if endDay.After(first) {
+			last = endDay.AddDate(0, 0, -1)
+		}
+	}
+	if summary == "" {
+		summary = "Holiday"
+	}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		holidays.Add(day, summary)
+	}
+	return nil
+}
+
+// parseICSDate reads the date part of an iCalendar DATE or DATE-TIME value.
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid calendar date %q", value)
+	}
+	day, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid calendar date %q", value)
+	}
+	return day, nil
+}
+
+// unfoldICSLines joins continuation lines, which start with a space or tab, onto the line before them.
+func unfoldICSLines(r io.Reader) []string {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,73 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, _ := time.Parse(time.DateOnly, s)
+	return d
+}
+
+func TestParseDateList(t *testing.T) {
+	holidays, err := ParseDateList([]string{"2026-12-25 Christmas Day", "2026-12-26", ""})
+	if err != nil {
+		t.Fatalf("ParseDateList() error = %v", err)
+	}
+	if !holidays.Contains(date("2026-12-25")) || holidays.Name(date("2026-12-25")) != "Christmas Day" {
+		t.Errorf("expected Christmas Day on 2026-12-25, got %v", holidays)
+	}
+	if holidays.Name(date("2026-12-26")) != "Holiday" {
+		t.Errorf("unnamed holiday should default to %q, got %q", "Holiday", holidays.Name(date("2026-12-26")))
+	}
+	if holidays.Contains(date("2026-12-24")) {
+		t.Error("2026-12-24 should not be a holiday")
+	}
+
+	if _, err := ParseDateList([]string{"25/12/2026"}); err == nil {
+		t.Error("expected error for non ISO date")
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20261225",
+		"DTEND;VALUE=DATE:20261227",
+		"SUMMARY:Christmas\\, Boxing",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20260831T000000Z",
+		"SUMMARY:Summer Bank Holiday",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	holidays, err := ParseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+	if len(holidays) != 3 {
+		t.Errorf("got %d holidays, want 3: %v", len(holidays), holidays)
+	}
+	if got := holidays.Name(date("2026-12-26")); got != "Christmas, Boxing Day" {
+		t.Errorf("Name(2026-12-26) = %q, want folded summary", got)
+	}
+	if holidays.Contains(date("2026-12-27")) {
+		t.Error("DTEND should be exclusive")
+	}
+	if !holidays.Contains(date("2026-08-31")) {
+		t.Error("expected single day event on 2026-08-31")
+	}
+}
+
+func TestParseICS_InvalidDate(t *testing.T) {
+	ics := "BEGIN:VEVENT\nDTSTART:soon\nEND:VEVENT\n"
+	if _, err := ParseICS(strings.NewReader(ics)); err == nil {
+		t.Error("expected error for invalid DTSTART")
+	}
+}
@@ -0,0 +1,196 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// daysInWeek is the number of calendar days in one week.
+const daysInWeek = 7
+
+// Week describes the day a week starts on, the weekdays that are worked and the time zone
+// that decides which date it is.
+type Week struct {
+	Start       time.Weekday
+	WorkingDays []time.Weekday
+	// Location is the zone dates are worked out in; nil leaves times in their own zone.
+	Location *time.Location
+}
+
+// DefaultWeek is a Monday to Friday working week starting on Monday.
+func DefaultWeek() Week {
+	return Week{
+		Start:       time.Monday,
+		WorkingDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	}
+}
+
+// NewWeek builds a week from a start day name and working day names, e.g. "sunday" and
+// ["sunday-thursday"]. Ranges wrap around the end of the week, so "fri-mon" is Friday to Monday.
+// An empty start or no working days keeps the matching part of DefaultWeek.
+func NewWeek(start string, workingDays []string) (Week, error) {
+	week := DefaultWeek()
+	if strings.TrimSpace(start) != "" {
+		weekday, err := ParseWeekday(start)
+		if err != nil {
+			return Week{}, err
+		}
+		week.Start = weekday
+	}
+	if len(workingDays) > 0 {
+		weekdays, err := ParseWeekdays(workingDays)
+		if err != nil {
+			return Week{}, err
+		}
+		week.WorkingDays = weekdays
+	}
+	return week, nil
+}
+
+// ParseWeekday accepts a full or three letter English day name in any case.
+func ParseWeekday(name string) (time.Weekday, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if normalized == full || (len(normalized) == 3 && normalized == full[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day of the week %q", name)
+}
+
+// ParseWeekdays parses day names and day ranges into a list of distinct weekdays in week order from Sunday.
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	selected := make([]bool, daysInWeek)
+	for _, name := range names {
+		from, to, isRange := strings.Cut(name, "-")
+		first, err := ParseWeekday(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = ParseWeekday(to); err != nil {
+				return nil, err
+			}
+		}
+		for day := first; ; day = (day + 1) % daysInWeek {
+			selected[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+
+	var weekdays []time.Weekday
+	for day, ok := range selected {
+		if ok {
+			weekdays = append(weekdays, time.Weekday(day))
+		}
+	}
+	if len(weekdays) == 0 {
+		return nil, fmt.Errorf("at least one working day is required")
+	}
+	return weekdays, nil
+}
+
+// LoadLocation loads an IANA time zone such as "Europe/London". An empty name is the machine's local zone.
+func LoadLocation(name string) (*time.Location, error) {
+	if strings.TrimSpace(name) == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %w", name, err)
+	}
+	return location, nil
+}
+
+// Now returns the current time in the week's zone.
+func (w Week) Now() time.Time {
+	if w.Location == nil {
+		return time.Now()
+	}
+	return time.Now().In(w.Location)
+}
+
+// ParseDate parses a YYYY-MM-DD date as midnight in the week's zone, or the local zone when it has none.
+func (w Week) ParseDate(value string) (time.Time, error) {
+	location := w.Location
+	if location == nil {
+		location = time.Local
+	}
+	return time.ParseInLocation(time.DateOnly, value, location)
+}
+
+// ParseISOWeek parses an ISO 8601 week such as "2026-W41" as midnight on its Monday in the week's zone,
+// or the local zone when it has none.
+func (w Week) ParseISOWeek(value string) (time.Time, error) {
+	var year, number int
+	if _, err := fmt.Sscanf(value, "%4d-W%2d", &year, &number); err != nil || len(value) != len("2006-W01") {
+		return time.Time{}, fmt.Errorf("invalid ISO week %q, expected YYYY-Www", value)
+	}
+	location := w.Location
+	if location == nil {
+		location = time.Local
+	}
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%daysInWeek)+(number-1)*daysInWeek)
+	if y, n := monday.ISOWeek(); number < 1 || y != year || n != number {
+		return time.Time{}, fmt.Errorf("%d has no ISO week %d", year, number)
+	}
+	return monday, nil
+}
+
+// ZoneName describes the week's zone for display, e.g. "Europe/London" or the local abbreviation.
+func (w Week) ZoneName() string {
+	now := w.Now()
+	if name := now.Location().String(); name != "Local" {
+		return name
+	}
+	abbreviation, _ := now.Zone()
+	return abbreviation
+}
+
+// StartOf returns the first day of the week containing day, keeping day's time of day.
+// day is first moved into the week's zone so the date is the one the user sees. Days are
+// stepped with AddDate, which counts calendar days and so stays on the right date across DST changes.
+func (w Week) StartOf(day time.Time) time.Time {
+	if w.Location != nil {
+		day = day.In(w.Location)
+	}
+	offset := (int(day.Weekday()) - int(w.Start) + daysInWeek) % daysInWeek
+	return day.AddDate(0, 0, -offset)
+}
+
+// Days returns all seven dates of the week starting at startOfWeek.
+func (w Week) Days(startOfWeek time.Time) []time.Time {
+	days := make([]time.Time, daysInWeek)
+	for i := range days {
+		days[i] = startOfWeek.AddDate(0, 0, i)
+	}
+	return days
+}
+
+// WorkingDaysFrom returns the working dates of the week starting at startOfWeek, in order.
+func (w Week) WorkingDaysFrom(startOfWeek time.Time) []time.Time {
+	days := []time.Time{}
+	for _, day := range w.Days(startOfWeek) {
+		if w.IsWorkingDay(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// IsWorkingDay reports whether day falls on one of the week's working weekdays.
+func (w Week) IsWorkingDay(day time.Time) bool {
+	for _, weekday := range w.WorkingDays {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
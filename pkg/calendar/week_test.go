@@ -0,0 +1,178 @@
+package calendar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewWeek(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       string
+		workingDays []string
+		want        Week
+		wantErr     bool
+	}{
+		{name: "defaults", want: DefaultWeek()},
+		{
+			name:        "sunday to thursday",
+			start:       "Sunday",
+			workingDays: []string{"sunday-thursday"},
+			want:        Week{Start: time.Sunday, WorkingDays: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday}},
+		},
+		{
+			name:        "listed days",
+			start:       "tue",
+			workingDays: []string{"tue", "wed", "thu", "fri", "sat"},
+			want:        Week{Start: time.Tuesday, WorkingDays: []time.Weekday{time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}},
+		},
+		{
+			name:        "range wrapping the weekend",
+			workingDays: []string{"fri-mon"},
+			want:        Week{Start: time.Monday, WorkingDays: []time.Weekday{time.Sunday, time.Monday, time.Friday, time.Saturday}},
+		},
+		{name: "unknown start", start: "funday", wantErr: true},
+		{name: "unknown working day", workingDays: []string{"mon-someday"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWeek(tt.start, tt.workingDays)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewWeek() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeekStartOf(t *testing.T) {
+	wednesday := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		start    time.Weekday
+		expected string
+	}{
+		{start: time.Monday, expected: "2026-10-12"},
+		{start: time.Sunday, expected: "2026-10-11"},
+		{start: time.Wednesday, expected: "2026-10-14"},
+		{start: time.Thursday, expected: "2026-10-08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.start.String(), func(t *testing.T) {
+			got := Week{Start: tt.start}.StartOf(wednesday)
+			if got.Format(time.DateOnly) != tt.expected {
+				t.Errorf("StartOf() = %s, want %s", got.Format(time.DateOnly), tt.expected)
+			}
+			if got.Hour() != 15 || got.Minute() != 30 {
+				t.Errorf("StartOf() = %v, want the time of day kept", got)
+			}
+		})
+	}
+}
+
+func TestWeekStartOfInLocation(t *testing.T) {
+	losAngeles, err := LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	week := DefaultWeek()
+	week.Location = losAngeles
+
+	// 02:00 UTC on Monday is still Sunday evening in Los Angeles
+	utcMonday := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+	if got := week.StartOf(utcMonday); got.Format(time.DateOnly) != "2026-10-12" {
+		t.Errorf("StartOf() = %s, want 2026-10-12", got.Format(time.DateOnly))
+	}
+
+	// the week containing the end of daylight saving time keeps whole calendar days
+	afterFallBack := time.Date(2026, 11, 6, 23, 30, 0, 0, losAngeles)
+	start := week.StartOf(afterFallBack)
+	if start.Format(time.DateOnly) != "2026-11-02" || start.Hour() != 23 {
+		t.Errorf("StartOf() = %v, want 2026-11-02 23:30", start)
+	}
+	springForward := time.Date(2027, 3, 14, 12, 0, 0, 0, losAngeles) // Sunday
+	days := week.Days(week.StartOf(springForward))
+	if days[6].Format(time.DateOnly) != "2027-03-14" {
+		t.Errorf("Days() ends on %s, want 2027-03-14", days[6].Format(time.DateOnly))
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	if location, err := LoadLocation(""); err != nil || location != time.Local {
+		t.Errorf("LoadLocation(\"\") = %v, %v; want Local", location, err)
+	}
+	if location, err := LoadLocation("Asia/Dubai"); err != nil || location.String() != "Asia/Dubai" {
+		t.Errorf("LoadLocation(Asia/Dubai) = %v, %v", location, err)
+	}
+	if _, err := LoadLocation("Mars/Olympus_Mons"); err == nil {
+		t.Error("expected error for unknown zone")
+	}
+}
+
+func TestWeekWorkingDaysFrom(t *testing.T) {
+	week, err := NewWeek("sunday", []string{"sun-thu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sunday := time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC)
+
+	days := week.WorkingDaysFrom(sunday)
+	var got []string
+	for _, day := range days {
+		got = append(got, day.Format(time.DateOnly))
+	}
+	want := []string{"2026-10-11", "2026-10-12", "2026-10-13", "2026-10-14", "2026-10-15"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WorkingDaysFrom() = %v, want %v", got, want)
+	}
+	if week.IsWorkingDay(sunday.AddDate(0, 0, 5)) {
+		t.Error("Friday should not be a working day")
+	}
+}
+
+func TestWeekParseISOWeek(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{value: "2026-W41", expected: "2026-10-05"},
+		{value: "2026-W01", expected: "2025-12-29"},
+		{value: "2026-W53", expected: "2026-12-28"},
+		{value: "2027-W53", wantErr: true},
+		{value: "2026-W00", wantErr: true},
+		{value: "2026-W4", wantErr: true},
+		{value: "2026-10-05", wantErr: true},
+	}
+
+	week := DefaultWeek()
+	week.Location = time.UTC
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := week.ParseISOWeek(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseISOWeek() = %s, want error", got.Format(time.DateOnly))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseISOWeek() error = %v", err)
+			}
+			if got.Format(time.DateOnly) != tt.expected || got.Location() != time.UTC {
+				t.Errorf("ParseISOWeek() = %v, want %s UTC", got, tt.expected)
+			}
+		})
+	}
+}